@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/signals"
+	"knative.dev/pkg/system"
+
+	"knative.dev/discovery/pkg/apiserver"
+	clusterducktypeinformer "knative.dev/discovery/pkg/client/injection/informers/discovery/v1alpha1/clusterducktype"
+)
+
+var (
+	port        = flag.Int("secure-port", 8443, "The port to serve the aggregated API on.")
+	certFile    = flag.String("tls-cert-file", "", "The serving certificate, a certificate is generated and kept in the secret if not set.")
+	keyFile     = flag.String("tls-private-key-file", "", "The key of the serving certificate.")
+	secretName  = flag.String("secret", "apiserver-certs", "The secret keeping the generated serving certificate.")
+	apiServices = flag.String("apiservices", "v1.duck.knative.dev", "The comma separated APIServices to set the CA of the generated serving certificate on.")
+)
+
+func main() {
+	ctx, startInformers := injection.EnableInjectionOrDie(signals.NewContext(), nil)
+	logger := logging.FromContext(ctx)
+
+	authn, err := apiserver.NewRequestHeaderAuthenticator(ctx, kubeclient.Get(ctx))
+	if err != nil {
+		logger.Fatalw("Failed to load the request header configuration", zap.Error(err))
+	}
+	handler := apiserver.NewServer(
+		clusterducktypeinformer.Get(ctx).Lister(),
+		dynamicclient.Get(ctx),
+		authn,
+		apiserver.NewSubjectAccessReviewAuthorizer(kubeclient.Get(ctx)),
+	)
+	startInformers()
+
+	// The aggregator presents its client certificate, it is verified by the
+	// authenticator so probes without a certificate are served too.
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequestClientCert,
+	}
+	if *certFile == "" {
+		keeper := apiserver.NewCertificateKeeper(kubeclient.Get(ctx), dynamicclient.Get(ctx),
+			system.Namespace(), *secretName, "apiserver", strings.Split(*apiServices, ","))
+		if err := keeper.Check(ctx); err != nil {
+			logger.Fatalw("Failed to load the serving certificate", zap.Error(err))
+		}
+		// The certificate is renewed before it expires, and served without
+		// a restart.
+		go keeper.Run(ctx)
+		tlsConfig.GetCertificate = keeper.GetCertificate
+	}
+
+	server := &http.Server{
+		Addr:      fmt.Sprintf(":%d", *port),
+		Handler:   handler,
+		TLSConfig: tlsConfig,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	logger.Infof("Serving the aggregated API on %s", server.Addr)
+	if err := server.ListenAndServeTLS(*certFile, *keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatalw("Failed to serve the aggregated API", zap.Error(err))
+	}
+}
@@ -19,6 +19,8 @@ package main
 import (
 	// The set of controllers this controller process runs.
 	"knative.dev/discovery/pkg/reconciler/clusterducktype"
+	"knative.dev/discovery/pkg/reconciler/ducktype"
+	"knative.dev/discovery/pkg/reconciler/manual"
 
 	// This defines the shared main for injected controllers.
 	"knative.dev/pkg/injection/sharedmain"
@@ -27,5 +29,7 @@ import (
 func main() {
 	sharedmain.Main("controller",
 		clusterducktype.NewController,
+		manual.NewController,
+		ducktype.NewController,
 	)
 }
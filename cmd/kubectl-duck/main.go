@@ -0,0 +1,31 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"log"
+
+	"knative.dev/discovery/pkg/commands"
+)
+
+// kubectl-duck is a kubectl plugin to explore the duck types of a cluster,
+// installed on the PATH it is run as `kubectl duck`.
+func main() {
+	if err := commands.New(nil).Execute(); err != nil {
+		log.Fatal("Error during command execution: ", err)
+	}
+}
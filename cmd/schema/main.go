@@ -28,6 +28,8 @@ import (
 // This is a demo of what the CLI looks like, copy and implement your own.
 func main() {
 	registry.Register(&v1alpha1.ClusterDuckType{})
+	registry.Register(&v1alpha1.Manual{})
+	registry.Register(&v1alpha1.DuckType{})
 
 	if err := commands.New("knative.dev/discovery").Execute(); err != nil {
 		log.Fatal("Error during command execution: ", err)
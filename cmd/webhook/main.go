@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/sharedmain"
@@ -32,15 +33,21 @@ import (
 	"knative.dev/pkg/webhook/certificates"
 	"knative.dev/pkg/webhook/configmaps"
 	"knative.dev/pkg/webhook/resourcesemantics"
+	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
+	"knative.dev/discovery/pkg/admission"
 	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	"knative.dev/discovery/pkg/apis/discovery/v1beta1"
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// List the types to validate.
 	v1alpha1.SchemeGroupVersion.WithKind("ClusterDuckType"): &v1alpha1.ClusterDuckType{},
+	v1alpha1.SchemeGroupVersion.WithKind("Manual"):          &v1alpha1.Manual{},
+	v1alpha1.SchemeGroupVersion.WithKind("DuckType"):        &v1alpha1.DuckType{},
+	v1beta1.SchemeGroupVersion.WithKind("ClusterDuckType"):  &v1beta1.ClusterDuckType{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
@@ -70,7 +77,7 @@ func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 }
 
 func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
-	return validation.NewAdmissionController(ctx,
+	impl := validation.NewAdmissionController(ctx,
 
 		// Name of the resource webhook.
 		fmt.Sprintf("validation.webhook.%s.knative.dev", system.Namespace()),
@@ -94,6 +101,9 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 		// Extra validating callbacks to be applied to resources.
 		callbacks,
 	)
+
+	// Warn about refs whose scope differs from the cluster.
+	return admission.WithWarnings(impl, admission.RefScopeWarnings(kubeclient.Get(ctx).Discovery()))
 }
 
 func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -113,6 +123,36 @@ func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *
 	)
 }
 
+func NewConversionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	var (
+		v1alpha1_ = v1alpha1.SchemeGroupVersion.Version
+		v1beta1_  = v1beta1.SchemeGroupVersion.Version
+	)
+
+	return conversion.NewConversionController(ctx,
+
+		// The path on which to serve the webhook.
+		"/resource-conversion",
+
+		// Specify the types of custom resource definitions that should be converted.
+		map[schema.GroupKind]conversion.GroupKindConversion{
+			v1alpha1.Kind("ClusterDuckType"): {
+				DefinitionName: "clusterducktypes.discovery.knative.dev",
+				HubVersion:     v1alpha1_,
+				Zygotes: map[string]conversion.ConvertibleObject{
+					v1alpha1_: &v1alpha1.ClusterDuckType{},
+					v1beta1_:  &v1beta1.ClusterDuckType{},
+				},
+			},
+		},
+
+		// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
+		func(ctx context.Context) context.Context {
+			return ctx
+		},
+	)
+}
+
 func main() {
 	ctx := webhook.WithOptions(signals.NewContext(), webhook.Options{
 		ServiceName: "webhook",
@@ -125,5 +165,6 @@ func main() {
 		NewDefaultingAdmissionController,
 		NewValidationAdmissionController,
 		NewConfigValidationController,
+		NewConversionController,
 	)
 }
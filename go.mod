@@ -8,6 +8,7 @@ require (
 	github.com/google/go-cmp v0.5.6
 	github.com/google/licenseclassifier v0.0.0-20200708223521-3d09a0ea2f39
 	github.com/sergi/go-diff v1.1.0 // indirect
+	github.com/spf13/cobra v1.1.3
 	go.uber.org/zap v1.19.1
 	gopkg.in/yaml.v2 v2.4.0
 	k8s.io/api v0.22.5
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	"knative.dev/discovery/pkg/apis/discovery/v1beta1"
+	"knative.dev/discovery/pkg/collection"
+)
+
+// RefScopeWarnings returns a WarningFunc warning about the refs of a duck
+// type whose scope is set and differs from the scope of their resource in the
+// cluster. The controller uses the scope of the cluster for the ducks of such
+// refs. Refs to resources unknown to the cluster are left to the controller.
+func RefScopeWarnings(client discovery.DiscoveryInterface) WarningFunc {
+	return func(ctx context.Context, req *admissionv1.AdmissionRequest) []string {
+		if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+			return nil
+		}
+		versions, err := duckVersions(ctx, req)
+		if err != nil {
+			logging.FromContext(ctx).Warnw("Unable to read the duck versions", zap.Error(err))
+			return nil
+		}
+
+		groupVersions := sets.NewString()
+		for _, dv := range versions {
+			for _, ref := range dv.Refs {
+				if ref.Scope != "" {
+					groupVersions.Insert(ref.GroupVersion())
+				}
+			}
+		}
+		lists := make([]*metav1.APIResourceList, 0, groupVersions.Len())
+		for _, gv := range groupVersions.List() {
+			list, err := client.ServerResourcesForGroupVersion(gv)
+			if err != nil {
+				continue
+			}
+			lists = append(lists, list)
+		}
+		mapper := collection.NewResourceMapper(lists)
+
+		var warnings []string
+		for _, dv := range versions {
+			for _, ref := range dv.Refs {
+				if ref.Scope == "" {
+					continue
+				}
+				scope, found := collection.RefScope(mapper, ref)
+				if !found || scope == ref.Scope {
+					continue
+				}
+				kind := ref.Kind
+				if kind == "" {
+					kind, _ = mapper.KindFor(ref.GroupVersion(), ref.Resource)
+				}
+				m := collection.RefScopeMismatch{
+					DuckVersion: dv.Name,
+					Ref:         ref,
+					Kind:        kind,
+					Scope:       scope,
+				}
+				warnings = append(warnings, fmt.Sprintf("%s, the scope of the cluster is used", m))
+			}
+		}
+		return warnings
+	}
+}
+
+// duckVersions reads the duck versions of the duck type in the request, or
+// none if the request is not for a duck type.
+func duckVersions(ctx context.Context, req *admissionv1.AdmissionRequest) ([]v1alpha1.DuckVersion, error) {
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	switch gvk {
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterDuckType"):
+		dt := &v1alpha1.ClusterDuckType{}
+		if err := json.Unmarshal(req.Object.Raw, dt); err != nil {
+			return nil, err
+		}
+		return dt.Spec.Versions, nil
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterDuckType"):
+		source := &v1beta1.ClusterDuckType{}
+		if err := json.Unmarshal(req.Object.Raw, source); err != nil {
+			return nil, err
+		}
+		dt := &v1alpha1.ClusterDuckType{}
+		if err := source.ConvertTo(ctx, dt); err != nil {
+			return nil, err
+		}
+		return dt.Spec.Versions, nil
+	case v1alpha1.SchemeGroupVersion.WithKind("DuckType"):
+		dt := &v1alpha1.DuckType{}
+		if err := json.Unmarshal(req.Object.Raw, dt); err != nil {
+			return nil, err
+		}
+		return dt.Spec.Versions, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestRefScopeWarnings(t *testing.T) {
+	client := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metav1.APIResourceList{{
+			GroupVersion: "north.america/v2",
+			APIResources: []metav1.APIResource{{
+				Name:       "gilamonsters",
+				Kind:       "GilaMonster",
+				Namespaced: false,
+			}, {
+				Name:       "ducks",
+				Kind:       "Duck",
+				Namespaced: true,
+			}},
+		}},
+	}}
+
+	clusterDuckType := metav1.GroupVersionKind{Group: "discovery.knative.dev", Version: "v1alpha1", Kind: "ClusterDuckType"}
+	tests := map[string]struct {
+		kind      metav1.GroupVersionKind
+		operation admissionv1.Operation
+		object    string
+		want      []string
+	}{
+		"misscoped kind": {
+			kind:      clusterDuckType,
+			operation: admissionv1.Create,
+			object: `{"spec": {"versions": [{"name": "v1", "refs": [
+				{"apiVersion": "north.america/v2", "kind": "GilaMonster", "scope": "Namespaced"},
+				{"apiVersion": "north.america/v2", "kind": "Duck", "scope": "Namespaced"}
+			]}]}}`,
+			want: []string{"v1: GilaMonster north.america/v2 has scope Cluster, not Namespaced, the scope of the cluster is used"},
+		},
+		"misscoped resource": {
+			kind:      clusterDuckType,
+			operation: admissionv1.Update,
+			object: `{"spec": {"versions": [{"name": "v1", "refs": [
+				{"group": "north.america", "version": "v2", "resource": "ducks", "scope": "Cluster"}
+			]}]}}`,
+			want: []string{"v1: Duck north.america/v2 has scope Namespaced, not Cluster, the scope of the cluster is used"},
+		},
+		"v1beta1": {
+			kind:      metav1.GroupVersionKind{Group: "discovery.knative.dev", Version: "v1beta1", Kind: "ClusterDuckType"},
+			operation: admissionv1.Create,
+			object: `{"spec": {"versions": [{"name": "v1", "refs": [
+				{"apiVersion": "north.america/v2", "kind": "GilaMonster", "scope": "Namespaced"}
+			]}]}}`,
+			want: []string{"v1: GilaMonster north.america/v2 has scope Cluster, not Namespaced, the scope of the cluster is used"},
+		},
+		"namespaced duck type": {
+			kind:      metav1.GroupVersionKind{Group: "discovery.knative.dev", Version: "v1alpha1", Kind: "DuckType"},
+			operation: admissionv1.Create,
+			object: `{"spec": {"versions": [{"name": "v2", "refs": [
+				{"apiVersion": "north.america/v2", "kind": "GilaMonster", "scope": "Namespaced"}
+			]}]}}`,
+			want: []string{"v2: GilaMonster north.america/v2 has scope Cluster, not Namespaced, the scope of the cluster is used"},
+		},
+		"matching scopes": {
+			kind:      clusterDuckType,
+			operation: admissionv1.Create,
+			object: `{"spec": {"versions": [{"name": "v1", "refs": [
+				{"apiVersion": "north.america/v2", "kind": "GilaMonster", "scope": "Cluster"}
+			]}]}}`,
+		},
+		"scope not set": {
+			kind:      clusterDuckType,
+			operation: admissionv1.Create,
+			object: `{"spec": {"versions": [{"name": "v1", "refs": [
+				{"apiVersion": "north.america/v2", "kind": "GilaMonster"}
+			]}]}}`,
+		},
+		"unknown kind": {
+			kind:      clusterDuckType,
+			operation: admissionv1.Create,
+			object: `{"spec": {"versions": [{"name": "v1", "refs": [
+				{"apiVersion": "south.america/v1", "kind": "Parrot", "scope": "Cluster"}
+			]}]}}`,
+		},
+		"delete": {
+			kind:      clusterDuckType,
+			operation: admissionv1.Delete,
+		},
+		"not a duck type": {
+			kind:      metav1.GroupVersionKind{Group: "discovery.knative.dev", Version: "v1alpha1", Kind: "Manual"},
+			operation: admissionv1.Create,
+			object:    `{"spec": {}}`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := &admissionv1.AdmissionRequest{
+				Kind:      tc.kind,
+				Operation: tc.operation,
+				Object:    runtime.RawExtension{Raw: []byte(tc.object)},
+			}
+			got := RefScopeWarnings(client)(context.Background(), req)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("warnings (-want, +got):", diff)
+			}
+		})
+	}
+}
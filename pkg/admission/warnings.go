@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/webhook"
+)
+
+// WarningFunc returns the warnings for a request an admission controller
+// allowed.
+type WarningFunc func(ctx context.Context, req *admissionv1.AdmissionRequest) []string
+
+// admissionReconciler is what the webhook expects from the reconciler of a
+// resource validation admission controller.
+type admissionReconciler interface {
+	controller.Reconciler
+	reconciler.LeaderAware
+	webhook.AdmissionController
+	webhook.StatelessAdmissionController
+}
+
+// warningAdmissionController adds warnings to the responses of the admission
+// controller it wraps.
+type warningAdmissionController struct {
+	admissionReconciler
+
+	warn WarningFunc
+}
+
+// WithWarnings wraps the admission controller of impl so the responses
+// allowing a request carry the warnings returned by warn. The admission
+// controllers of knative.dev/pkg do not return warnings themselves. impl is
+// returned unchanged if its reconciler is not an admission controller.
+func WithWarnings(impl *controller.Impl, warn WarningFunc) *controller.Impl {
+	if ac, ok := impl.Reconciler.(admissionReconciler); ok {
+		impl.Reconciler = &warningAdmissionController{
+			admissionReconciler: ac,
+			warn:                warn,
+		}
+	}
+	return impl
+}
+
+// Admit implements webhook.AdmissionController
+func (ac *warningAdmissionController) Admit(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	resp := ac.admissionReconciler.Admit(ctx, req)
+	if resp != nil && resp.Allowed {
+		resp.Warnings = append(resp.Warnings, ac.warn(ctx, req)...)
+	}
+	return resp
+}
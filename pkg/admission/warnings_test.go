@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/webhook"
+)
+
+// fakeAdmissionController allows the requests named "allowed".
+type fakeAdmissionController struct {
+	reconciler.LeaderAwareFuncs
+}
+
+func (*fakeAdmissionController) Reconcile(ctx context.Context, key string) error { return nil }
+
+func (*fakeAdmissionController) Path() string { return "/validation" }
+
+func (*fakeAdmissionController) ThisTypeDoesNotDependOnInformerState() {}
+
+func (*fakeAdmissionController) Admit(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed:  req.Name == "allowed",
+		Warnings: []string{"existing"},
+	}
+}
+
+func TestWithWarnings(t *testing.T) {
+	impl := WithWarnings(&controller.Impl{Reconciler: &fakeAdmissionController{}}, func(ctx context.Context, req *admissionv1.AdmissionRequest) []string {
+		return []string{"added"}
+	})
+
+	ac, ok := impl.Reconciler.(webhook.AdmissionController)
+	if !ok {
+		t.Fatalf("%T is not an admission controller", impl.Reconciler)
+	}
+	if _, ok := impl.Reconciler.(reconciler.LeaderAware); !ok {
+		t.Errorf("%T is not leader aware", impl.Reconciler)
+	}
+	if got, want := ac.Path(), "/validation"; got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+
+	tests := map[string][]string{
+		"allowed": {"existing", "added"},
+		"denied":  {"existing"},
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := ac.Admit(context.Background(), &admissionv1.AdmissionRequest{Name: name})
+			if diff := cmp.Diff(want, resp.Warnings); diff != "" {
+				t.Error("warnings (-want, +got):", diff)
+			}
+		})
+	}
+}
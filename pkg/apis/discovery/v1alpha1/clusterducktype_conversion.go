@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible.
+// v1alpha1 is the hub version, other versions convert to and from it.
+func (source *ClusterDuckType) ConvertTo(ctx context.Context, sink apis.Convertible) error {
+	return fmt.Errorf("v1alpha1 is the hub version, got: %T", sink)
+}
+
+// ConvertFrom implements apis.Convertible.
+// v1alpha1 is the hub version, other versions convert to and from it.
+func (sink *ClusterDuckType) ConvertFrom(ctx context.Context, source apis.Convertible) error {
+	return fmt.Errorf("v1alpha1 is the hub version, got: %T", source)
+}
@@ -61,7 +61,7 @@ func TestDuckTypeDefaulting(t *testing.T) {
 					},
 				}},
 		},
-		"default to namespaced": {
+		"scope of refs not defaulted": {
 			in: &ClusterDuckType{
 				Spec: ClusterDuckTypeSpec{
 					Names: DuckTypeNames{
@@ -87,11 +87,35 @@ func TestDuckTypeDefaulting(t *testing.T) {
 						Refs: []ResourceRef{{
 							APIVersion: "needs.scope/v1",
 							Kind:       "NeedsScope",
-							Scope:      NamespaceScoped,
 						}},
 					}},
 				}},
 		},
+		"default managed role verbs": {
+			in: &ClusterDuckType{
+				Spec: ClusterDuckTypeSpec{
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Singular: "thisduck",
+					},
+					Role: &Role{
+						Managed: &ManagedRole{AggregateTo: "viewer"},
+					},
+				}},
+			want: &ClusterDuckType{
+				Spec: ClusterDuckTypeSpec{
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Singular: "thisduck",
+					},
+					Role: &Role{
+						Managed: &ManagedRole{
+							AggregateTo: "viewer",
+							Verbs:       []string{"get", "list", "watch"},
+						},
+					},
+				}},
+		},
 	}
 
 	for name, tc := range tests {
@@ -21,7 +21,11 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var duckTypeCondSet = apis.NewLivingConditionSet()
+var duckTypeCondSet = apis.NewLivingConditionSet(
+	DuckTypeConditionCRDsDiscovered,
+	DuckTypeConditionRoleResolved,
+	DuckTypeConditionRefsResolved,
+)
 
 // GetGroupVersionKind implements kmeta.OwnerRefable
 func (*ClusterDuckType) GetGroupVersionKind() schema.GroupVersionKind {
@@ -38,7 +42,82 @@ func (dts *ClusterDuckTypeStatus) InitializeConditions() {
 	duckTypeCondSet.Manage(dts).InitializeConditions()
 }
 
-// TODO: temp function, delete later as we develop the reconciler.
-func (dts *ClusterDuckTypeStatus) MarkReady() {
-	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionReady)
+// MarkCRDsDiscovered sets the CRDsDiscovered condition to true.
+func (dts *ClusterDuckTypeStatus) MarkCRDsDiscovered() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionCRDsDiscovered)
+}
+
+// MarkCRDsNotDiscovered sets the CRDsDiscovered condition to false with the
+// given reason and message.
+func (dts *ClusterDuckTypeStatus) MarkCRDsNotDiscovered(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionCRDsDiscovered, reason, messageFormat, messageA...)
+}
+
+// MarkRoleResolved sets the RoleResolved condition to true.
+func (dts *ClusterDuckTypeStatus) MarkRoleResolved() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionRoleResolved)
+}
+
+// MarkRoleResolvedWithReason sets the RoleResolved condition to true with the
+// given reason and message. It is used when there is no Role to resolve.
+func (dts *ClusterDuckTypeStatus) MarkRoleResolvedWithReason(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkTrueWithReason(DuckTypeConditionRoleResolved, reason, messageFormat, messageA...)
+}
+
+// MarkRoleUnresolved sets the RoleResolved condition to false with the given
+// reason and message.
+func (dts *ClusterDuckTypeStatus) MarkRoleUnresolved(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionRoleResolved, reason, messageFormat, messageA...)
+}
+
+// MarkRefsResolved sets the RefsResolved condition to true.
+func (dts *ClusterDuckTypeStatus) MarkRefsResolved() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionRefsResolved)
+}
+
+// MarkRefsResolvedWithReason sets the RefsResolved condition to true with the
+// given reason and message. It is used when refs were resolved differently
+// than they are described.
+func (dts *ClusterDuckTypeStatus) MarkRefsResolvedWithReason(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkTrueWithReason(DuckTypeConditionRefsResolved, reason, messageFormat, messageA...)
+}
+
+// MarkRefsUnresolved sets the RefsResolved condition to false with the given
+// reason and message.
+func (dts *ClusterDuckTypeStatus) MarkRefsUnresolved(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionRefsResolved, reason, messageFormat, messageA...)
+}
+
+// MarkDiscoveryHealthy sets the DiscoveryHealthy condition to true.
+func (dts *ClusterDuckTypeStatus) MarkDiscoveryHealthy() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionDiscoveryHealthy)
+}
+
+// MarkDiscoveryUnhealthy sets the DiscoveryHealthy condition to false with the
+// given reason and message.
+func (dts *ClusterDuckTypeStatus) MarkDiscoveryUnhealthy(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionDiscoveryHealthy, reason, messageFormat, messageA...)
+}
+
+// MarkInstancesCounted sets the InstancesCounted condition to true.
+func (dts *ClusterDuckTypeStatus) MarkInstancesCounted() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionInstancesCounted)
+}
+
+// MarkInstancesNotCounted sets the InstancesCounted condition to false with
+// the given reason and message.
+func (dts *ClusterDuckTypeStatus) MarkInstancesNotCounted(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionInstancesCounted, reason, messageFormat, messageA...)
+}
+
+// MarkInstancesCountUnknown sets the InstancesCounted condition to unknown
+// with the given reason and message.
+func (dts *ClusterDuckTypeStatus) MarkInstancesCountUnknown(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkUnknown(DuckTypeConditionInstancesCounted, reason, messageFormat, messageA...)
+}
+
+// ClearInstancesCounted removes the InstancesCounted condition, when the
+// instances are not counted.
+func (dts *ClusterDuckTypeStatus) ClearInstancesCounted() {
+	_ = duckTypeCondSet.Manage(dts).ClearCondition(DuckTypeConditionInstancesCounted)
 }
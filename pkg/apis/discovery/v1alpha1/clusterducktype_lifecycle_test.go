@@ -79,7 +79,10 @@ func TestDuckTypeInitializeConditions(t *testing.T) {
 
 	// These are already sorted.
 	expected := []string{
+		string(DuckTypeConditionCRDsDiscovered),
 		string(DuckTypeConditionReady),
+		string(DuckTypeConditionRefsResolved),
+		string(DuckTypeConditionRoleResolved),
 	}
 
 	sort.Strings(types)
@@ -89,12 +92,87 @@ func TestDuckTypeInitializeConditions(t *testing.T) {
 	}
 }
 
-func TestDuckTypeMarkReady(t *testing.T) {
-	rs := &ClusterDuckTypeStatus{}
-	rs.MarkReady()
+func TestDuckTypeConditions(t *testing.T) {
+	tests := []struct {
+		name  string
+		mark  func(*ClusterDuckTypeStatus)
+		ready corev1.ConditionStatus
+	}{{
+		name: "all resolved",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsDiscovered()
+			rs.MarkRoleResolved()
+			rs.MarkRefsResolved()
+		},
+		ready: corev1.ConditionTrue,
+	}, {
+		name: "no role",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsDiscovered()
+			rs.MarkRoleResolvedWithReason("NoRole", "no role")
+			rs.MarkRefsResolved()
+		},
+		ready: corev1.ConditionTrue,
+	}, {
+		name: "refs scope mismatch",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsDiscovered()
+			rs.MarkRoleResolved()
+			rs.MarkRefsResolvedWithReason("ScopeMismatch", "v1: Pond has scope Cluster, not Namespaced")
+		},
+		ready: corev1.ConditionTrue,
+	}, {
+		name: "discovery unhealthy",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsDiscovered()
+			rs.MarkRoleResolved()
+			rs.MarkRefsResolved()
+			rs.MarkDiscoveryUnhealthy("GroupDiscoveryFailed", "metrics.k8s.io/v1beta1: unavailable")
+		},
+		ready: corev1.ConditionTrue,
+	}, {
+		name: "crds not discovered",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsNotDiscovered("CRDListFailed", "boom")
+			rs.MarkRoleResolved()
+			rs.MarkRefsResolved()
+		},
+		ready: corev1.ConditionFalse,
+	}, {
+		name: "role unresolved",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsDiscovered()
+			rs.MarkRoleUnresolved("RoleNotFound", "not found")
+			rs.MarkRefsResolved()
+		},
+		ready: corev1.ConditionFalse,
+	}, {
+		name: "refs unresolved",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsDiscovered()
+			rs.MarkRoleResolved()
+			rs.MarkRefsUnresolved("RefsNotFound", "not found")
+		},
+		ready: corev1.ConditionFalse,
+	}, {
+		name: "refs pending",
+		mark: func(rs *ClusterDuckTypeStatus) {
+			rs.MarkCRDsDiscovered()
+			rs.MarkRoleResolved()
+		},
+		ready: corev1.ConditionUnknown,
+	}}
 
-	c := rs.GetCondition(DuckTypeConditionReady)
-	if c == nil || c.Status != corev1.ConditionTrue {
-		t.Errorf("expected Ready to be true, got %v\n", c)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &ClusterDuckTypeStatus{}
+			rs.InitializeConditions()
+			tt.mark(rs)
+
+			c := rs.GetCondition(DuckTypeConditionReady)
+			if c == nil || c.Status != tt.ready {
+				t.Errorf("expected Ready to be %s, got %v\n", tt.ready, c)
+			}
+		})
 	}
 }
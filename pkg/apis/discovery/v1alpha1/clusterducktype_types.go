@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,9 +49,10 @@ type ClusterDuckType struct {
 }
 
 var (
-	// Check that ClusterDuckType can be validated and defaulted.
+	// Check that ClusterDuckType can be validated, defaulted and converted.
 	_ apis.Validatable   = (*ClusterDuckType)(nil)
 	_ apis.Defaultable   = (*ClusterDuckType)(nil)
+	_ apis.Convertible   = (*ClusterDuckType)(nil)
 	_ kmeta.OwnerRefable = (*ClusterDuckType)(nil)
 	// Check that the type conforms to the duck Knative Resource shape.
 	_ duckv1.KRShaped = (*ClusterDuckType)(nil)
@@ -74,10 +76,35 @@ type ClusterDuckTypeSpec struct {
 	// +optional
 	Selectors []CustomResourceDefinitionSelector `json:"selectors,omitempty"`
 
+	// ExcludeSelectors is a list of selectors for CustomResourceDefinitions
+	// that do not implement the duck type, even if Selectors match them.
+	// +optional
+	ExcludeSelectors []CustomResourceDefinitionSelector `json:"excludeSelectors,omitempty"`
+
+	// SelectorMode is how the CRDs matching each of the Selectors are
+	// combined. `Union` selects the CRDs matching any of the selectors and
+	// `Intersection` the CRDs matching all of them. Defaults to `Union`.
+	// +optional
+	SelectorMode SelectorMode `json:"selectorMode,omitempty"`
+
 	//Role holds an Aggregating Role used by the duck type to manage the ducks.
 	//  If not specified, the Selectors are used to find a Role with an aggregation rule that matches a selector
 	// +optional
 	Role *Role `json:"role,omitempty"`
+
+	// DiscoverBuiltIns matches the built-in and aggregated kinds served by
+	// the API server against the Schema of each duck version, using the
+	// OpenAPI v3 documents of the API server. Kinds that conform are added to
+	// the ducks. Only duck versions whose schema has properties are matched.
+	// +optional
+	DiscoverBuiltIns bool `json:"discoverBuiltIns,omitempty"`
+
+	// CountInstances has the controller count the instances of the ducks and
+	// sum up their readiness. Only the ducks the aggregating ClusterRole
+	// grants get, list and watch on, and that the controller can list, are
+	// counted.
+	// +optional
+	CountInstances bool `json:"countInstances,omitempty"`
 }
 
 // Role provides a way of specifying which Aggregating Role is used by the duck type to manage the ducks
@@ -85,6 +112,38 @@ type Role struct {
 	// RoleRef is a reference to the Aggregating Role
 	// +optional
 	RoleRef *rbacv1.RoleRef `json:"roleRef,omitempty"`
+
+	// Subject is the ServiceAccount that uses the ducks. If set, the access
+	// of the subject to each duck is checked with SubjectAccessReviews.
+	// +optional
+	Subject *rbacv1.Subject `json:"subject,omitempty"`
+
+	// Managed has the reconciler maintain a ClusterRole that grants access to
+	// the ducks, labeled to be aggregated into another ClusterRole.
+	// +optional
+	Managed *ManagedRole `json:"managed,omitempty"`
+}
+
+// ManagedRole configures the ClusterRole maintained for the ducks of a duck
+// type. The ClusterRole is owned by the duck type and is named after it.
+type ManagedRole struct {
+	// AggregateTo is the name of the ClusterRole the managed ClusterRole is
+	// aggregated into. The managed ClusterRole is labeled
+	// `rbac.authorization.k8s.io/aggregate-to-<aggregateTo>: "true"`. The
+	// built-in admin, edit, view, cluster-admin and system: ClusterRoles are
+	// not allowed.
+	AggregateTo string `json:"aggregateTo"`
+
+	// Verbs are granted on every resource of the ducks, only get, list and
+	// watch are allowed. Defaults to get, list and watch.
+	// +optional
+	Verbs []string `json:"verbs,omitempty"`
+}
+
+// AggregationLabel returns the label that aggregates the managed ClusterRole
+// into the ClusterRole named by AggregateTo.
+func (mr *ManagedRole) AggregationLabel() string {
+	return "rbac.authorization.k8s.io/aggregate-to-" + mr.AggregateTo
 }
 
 // DuckTypeNames provides the naming rules for this duck type.
@@ -121,12 +180,10 @@ type DuckVersion struct {
 	Schema *apiextensionsv1.CustomResourceValidation `json:"schema,omitempty"`
 }
 
-// CustomResourceDefinitionSelector
+// CustomResourceDefinitionSelector selects CustomResourceDefinitions that
+// implement the duck type. The CRDs matching LabelSelector are narrowed by
+// the other fields of the selector, a CRD must match all of them.
 type CustomResourceDefinitionSelector struct {
-	// Note: in the future we could also add a filtering component here to
-	// limit the matches based on another field or annotation. Leaving this as
-	// a sub-object for that future.
-
 	// LabelSelector is a label selector used to find CRDs that associate with
 	// the duck type.
 	// Typically this will be in the form:
@@ -142,8 +199,42 @@ type CustomResourceDefinitionSelector struct {
 	// as the match.
 	// Must be a valid Kubernetes Label Selector.
 	LabelSelector string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
+
+	// AnnotationSelector narrows the CRDs matching LabelSelector to the CRDs
+	// with matching annotations. It uses the syntax of label selectors, for
+	// example `duck.knative.dev/vendor in (acme)`.
+	// +optional
+	AnnotationSelector string `json:"annotationSelector,omitempty" yaml:"annotationSelector,omitempty"`
+
+	// Groups narrows the matching CRDs to the CRDs of one of the API groups.
+	// +optional
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+
+	// Scope narrows the matching CRDs to the CRDs of the scope.
+	// +optional, allowed values are `Cluster` and `Namespaced`.
+	Scope ResourceScope `json:"scope,omitempty" yaml:"scope,omitempty"`
+
+	// Kinds narrows the matching CRDs to the CRDs of one of the kinds.
+	// +optional
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+
+	// Categories narrows the matching CRDs to the CRDs in at least one of the
+	// categories.
+	// +optional
+	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"`
 }
 
+// SelectorMode is an enum defining how the CRDs matching several selectors
+// are combined.
+type SelectorMode string
+
+const (
+	// UnionSelectorMode selects the CRDs matching any of the selectors.
+	UnionSelectorMode SelectorMode = "Union"
+	// IntersectionSelectorMode selects the CRDs matching all the selectors.
+	IntersectionSelectorMode SelectorMode = "Intersection"
+)
+
 // ResourceScope is an enum defining the different scopes available to a custom resource
 type ResourceScope string
 
@@ -152,6 +243,16 @@ const (
 	NamespaceScoped ResourceScope = "Namespaced"
 )
 
+// ResourceSource is how a duck was discovered, when it was not through a CRD
+// or a ref.
+type ResourceSource string
+
+const (
+	// OpenAPISource marks the ducks matched against the OpenAPI v3 documents
+	// of the API server.
+	OpenAPISource ResourceSource = "OpenAPI"
+)
+
 // ResourceRef points to a Kubernetes Resource kind.
 type ResourceRef struct {
 	// Group is the resource group.
@@ -176,8 +277,10 @@ type ResourceRef struct {
 	Kind string `json:"kind,omitempty"`
 
 	// Scope indicates whether the resource is cluster- or namespace-scoped.
-	// +optional, allowed values are `Cluster` and `Namespaced`, defaults to "Namespaced".
-	Scope ResourceScope `json:"scope"`
+	// The ducks take the scope of the resource in the cluster, a scope that
+	// differs is reported.
+	// +optional, allowed values are `Cluster` and `Namespaced`.
+	Scope ResourceScope `json:"scope,omitempty"`
 }
 
 // APIVersion puts "group" and "version" into a single "group/version" string
@@ -200,12 +303,73 @@ type ResourceMeta struct {
 	// Kind is the CamelCased resource kind.
 	Kind string `json:"kind"`
 
+	// Resource is the plural resource name.
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
 	// Scope indicates whether the resource is cluster- or namespace-scoped.
 	// Allowed values are `Cluster` and `Namespaced`.
 	Scope ResourceScope `json:"scope"`
 
 	// AccessibleViaClusterRole indicates whether the provided ClusterDuckType Role can perform get, list & watch on the resource
 	AccessibleViaClusterRole bool `json:"accessibleByClusterRole"`
+
+	// AccessibleBySubject holds, for each verb checked, whether the Subject of
+	// the ClusterDuckType Role can perform it on the resource.
+	// +optional
+	AccessibleBySubject map[string]bool `json:"accessibleBySubject,omitempty"`
+
+	// Storage indicates the version is the storage version of the CRD.
+	// +optional
+	Storage bool `json:"storage,omitempty"`
+
+	// Deprecated indicates the version of the CRD is deprecated.
+	// +optional
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// DeprecationWarning is the warning returned to API clients of a
+	// deprecated version of the CRD.
+	// +optional
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
+
+	// Preferred indicates the version is the one to use for the kind among the
+	// versions of the same duck version. Versions that are not deprecated are
+	// preferred, then the highest version.
+	// +optional
+	Preferred bool `json:"preferred,omitempty"`
+
+	// Source is how the resource was discovered. It is empty for resources
+	// found through CRDs and refs.
+	// +optional
+	Source ResourceSource `json:"source,omitempty"`
+
+	// Verbs are the verbs the API server supports on the resource, as listed
+	// by discovery.
+	// +optional
+	Verbs []string `json:"verbs,omitempty"`
+
+	// Subresources are the `status` and `scale` subresources served for the
+	// resource, as listed by discovery.
+	// +optional
+	Subresources []string `json:"subresources,omitempty"`
+}
+
+// PreferredVersions returns one ResourceMeta for each kind of the metas, the
+// preferred version if one is marked, or else the first version given for the
+// kind. Kinds are returned in the order they first appear.
+func PreferredVersions(metas []ResourceMeta) []ResourceMeta {
+	index := make(map[string]int)
+	preferred := make([]ResourceMeta, 0, len(metas))
+	for _, meta := range metas {
+		key := strings.ToLower(meta.Group() + "/" + meta.Kind)
+		if i, found := index[key]; !found {
+			index[key] = len(preferred)
+			preferred = append(preferred, meta)
+		} else if meta.Preferred && !preferred[i].Preferred {
+			preferred[i] = meta
+		}
+	}
+	return preferred
 }
 
 // Version inspects a ResourceMeta object and returns the correct version
@@ -228,10 +392,125 @@ func (r *ResourceMeta) Group() string {
 	return ""
 }
 
+// NonConformingResourceMeta is a ResourceMeta of a resource that was selected
+// for the duck type, but does not satisfy the schema of the duck version.
+type NonConformingResourceMeta struct {
+	ResourceMeta `json:",inline"`
+
+	// MismatchedFields holds the paths of the fields of the duck version
+	// schema that the schema of the resource does not satisfy.
+	MismatchedFields []string `json:"mismatchedFields"`
+}
+
+// UnresolvedResourceRef is a ResourceRef of a duck version that could not be
+// resolved to a resource known to the cluster.
+type UnresolvedResourceRef struct {
+	ResourceRef `json:",inline"`
+
+	// Error is the reason the ref could not be resolved.
+	Error string `json:"error"`
+}
+
+// SkippedCRD is a CRD selected for the duck type that was not hunted for ducks
+// because the API server does not serve it as it is described.
+type SkippedCRD struct {
+	// Name is the name of the CRD.
+	Name string `json:"name"`
+	// Reason is a one-word CamelCase reason the CRD was skipped.
+	Reason string `json:"reason"`
+	// Message is a human readable explanation of why the CRD was skipped.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// InstanceCount is the number of instances of a kind of duck on the cluster.
+type InstanceCount struct {
+	// APIVersion is the version of the resource that was watched to count the
+	// instances.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Count is the total number of instances.
+	Count int `json:"count"`
+
+	// Namespaces maps namespaces to the number of instances in them.
+	// Instances of cluster scoped resources are only part of Count.
+	// +optional
+	Namespaces map[string]int `json:"namespaces,omitempty"`
+}
+
+// ReadinessRollup sums up the Ready condition of the instances of the ducks.
+type ReadinessRollup struct {
+	// Ready is the number of instances that are ready.
+	Ready int `json:"ready"`
+	// NotReady is the number of instances that are not ready.
+	NotReady int `json:"notReady"`
+	// Unknown is the number of instances without a Ready condition, with an
+	// unknown Ready condition, or with a status that is older than their spec.
+	Unknown int `json:"unknown"`
+
+	// WorstOffenders lists the instances that are not ready first, and then
+	// the instances whose readiness is unknown, each ordered by how long they
+	// have been in that state. The list is capped at ten instances.
+	// +optional
+	WorstOffenders []NotReadyInstance `json:"worstOffenders,omitempty"`
+}
+
+// NotReadyInstance is an instance of a duck that is not ready.
+type NotReadyInstance struct {
+	// APIVersion is the version of the resource of the instance.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource of the instance.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the instance, empty for cluster scoped
+	// resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the instance.
+	Name string `json:"name"`
+
+	// Status is the status of the Ready condition of the instance, False or
+	// Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Reason is the reason of the Ready condition of the instance.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is the message of the Ready condition of the instance.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the Ready condition of the instance
+	// changed.
+	// +optional
+	LastTransitionTime apis.VolatileTime `json:"lastTransitionTime,omitempty"`
+}
+
 const (
 	// DuckTypeConditionReady is set when the revision is starting to materialize
 	// runtime resources, and becomes true when those resources are ready.
 	DuckTypeConditionReady = apis.ConditionReady
+
+	// DuckTypeConditionCRDsDiscovered is set when the CustomResourceDefinitions
+	// matching the selectors of the duck type could be listed.
+	DuckTypeConditionCRDsDiscovered apis.ConditionType = "CRDsDiscovered"
+
+	// DuckTypeConditionRoleResolved is set when the Role used to decide which
+	// ducks are accessible could be resolved.
+	DuckTypeConditionRoleResolved apis.ConditionType = "RoleResolved"
+
+	// DuckTypeConditionRefsResolved is set when every ref of the duck type
+	// versions is known to the cluster.
+	DuckTypeConditionRefsResolved apis.ConditionType = "RefsResolved"
+
+	// DuckTypeConditionDiscoveryHealthy is set when every group version served
+	// by the cluster could be discovered. It does not affect readiness, the
+	// ducks of the group versions that were discovered are kept.
+	DuckTypeConditionDiscoveryHealthy apis.ConditionType = "DiscoveryHealthy"
+
+	// DuckTypeConditionInstancesCounted is set when the instances of every
+	// duck are counted. It is only set if the instances are counted, and does
+	// not affect readiness.
+	DuckTypeConditionInstancesCounted apis.ConditionType = "InstancesCounted"
 )
 
 // ClusterDuckTypeStatus communicates the observed state of the ClusterDuckType (from the controller).
@@ -244,6 +523,33 @@ type ClusterDuckTypeStatus struct {
 	// DuckCount is the count of unique duck types found post-hunt.
 	DuckCount int `json:"duckCount"`
 
+	// NonConformingDucks is a versioned mapping of the found resources that
+	// were selected for this duck, but do not satisfy the schema of the duck
+	// version. These are not part of Ducks.
+	// +optional
+	NonConformingDucks map[string][]NonConformingResourceMeta `json:"nonConformingDucks,omitempty"`
+
+	// UnresolvedRefs is a versioned mapping of the refs of the duck versions
+	// that could not be resolved. These are not part of Ducks.
+	// +optional
+	UnresolvedRefs map[string][]UnresolvedResourceRef `json:"unresolvedRefs,omitempty"`
+
+	// SkippedCRDs lists the selected CRDs that are not established, or whose
+	// names were not accepted, ordered by name. These are not part of Ducks.
+	// +optional
+	SkippedCRDs []SkippedCRD `json:"skippedCRDs,omitempty"`
+
+	// Instances holds the number of instances of each kind of duck found
+	// post-hunt, ordered by API version and kind. Kinds whose instances are
+	// not known yet are left out.
+	// +optional
+	Instances []InstanceCount `json:"instances,omitempty"`
+
+	// Readiness sums up the Ready condition of the instances counted in
+	// Instances.
+	// +optional
+	Readiness *ReadinessRollup `json:"readiness,omitempty"`
+
 	//ClusterRole Aggregation Rule
 	ClusterRoleAggregationRule rbacv1.AggregationRule `json:"clusterRoleAggregationRule,omitempty"`
 }
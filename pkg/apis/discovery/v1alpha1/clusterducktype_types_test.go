@@ -223,3 +223,38 @@ func TestResourceMeta_Version(t *testing.T) {
 		})
 	}
 }
+
+func TestPreferredVersions(t *testing.T) {
+	metas := []ResourceMeta{{
+		APIVersion: "north.america/v1alpha2",
+		Kind:       "Duck",
+	}, {
+		APIVersion: "north.america/v1beta1",
+		Kind:       "Duck",
+		Preferred:  true,
+	}, {
+		APIVersion: "north.america/v2",
+		Kind:       "GilaMonster",
+	}, {
+		APIVersion: "south.america/v1",
+		Kind:       "Duck",
+	}, {
+		APIVersion: "north.america/v2beta1",
+		Kind:       "GilaMonster",
+	}}
+
+	want := []ResourceMeta{{
+		APIVersion: "north.america/v1beta1",
+		Kind:       "Duck",
+		Preferred:  true,
+	}, {
+		APIVersion: "north.america/v2",
+		Kind:       "GilaMonster",
+	}, {
+		APIVersion: "south.america/v1",
+		Kind:       "Duck",
+	}}
+	if diff := cmp.Diff(want, PreferredVersions(metas)); diff != "" {
+		t.Error("PreferredVersions (-want, +got):", diff)
+	}
+}
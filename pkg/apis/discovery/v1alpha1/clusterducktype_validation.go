@@ -21,7 +21,10 @@ import (
 	"fmt"
 	"strings"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"knative.dev/pkg/apis"
 )
@@ -59,12 +62,97 @@ func (dts *ClusterDuckTypeSpec) Validate(ctx context.Context) (errs *apis.FieldE
 	}
 
 	for i, st := range dts.Selectors {
-		_, err := labels.Parse(st.LabelSelector)
-		if err != nil {
-			errs = errs.Also(apis.ErrInvalidValue(st.LabelSelector, "labelSelector").ViaFieldIndex("selectors", i))
+		errs = errs.Also(st.Validate(ctx).ViaFieldIndex("selectors", i))
+	}
+	for i, st := range dts.ExcludeSelectors {
+		errs = errs.Also(st.Validate(ctx).ViaFieldIndex("excludeSelectors", i))
+	}
+	switch dts.SelectorMode {
+	case "", UnionSelectorMode, IntersectionSelectorMode:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(dts.SelectorMode, "selectorMode"))
+	}
+	if dts.Role != nil {
+		errs = errs.Also(dts.Role.Validate(ctx).ViaField("role"))
+	}
+	if dts.DiscoverBuiltIns && !hasSchemaProperties(dts.Versions) {
+		errs = errs.Also(apis.ErrGeneric("a version with a schema is required to discover built-in kinds", "discoverBuiltIns"))
+	}
+
+	return errs
+}
+
+// hasSchemaProperties returns true if one of the versions has a schema with
+// properties to match kinds against.
+func hasSchemaProperties(versions []DuckVersion) bool {
+	for _, v := range versions {
+		if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil && len(v.Schema.OpenAPIV3Schema.Properties) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate implements apis.Validatable
+func (st *CustomResourceDefinitionSelector) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if _, err := labels.Parse(st.LabelSelector); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(st.LabelSelector, "labelSelector"))
+	}
+	if _, err := labels.Parse(st.AnnotationSelector); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(st.AnnotationSelector, "annotationSelector"))
+	}
+	switch st.Scope {
+	case "", ClusterScoped, NamespaceScoped:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(st.Scope, "scope"))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (r *Role) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Subject != nil {
+		var serrs *apis.FieldError
+		if r.Subject.Kind != rbacv1.ServiceAccountKind {
+			serrs = serrs.Also(apis.ErrInvalidValue(r.Subject.Kind, "kind"))
+		}
+		if r.Subject.Name == "" {
+			serrs = serrs.Also(apis.ErrMissingField("name"))
 		}
+		if r.Subject.Namespace == "" {
+			serrs = serrs.Also(apis.ErrMissingField("namespace"))
+		}
+		errs = errs.Also(serrs.ViaField("subject"))
+	}
+	if r.Managed != nil {
+		errs = errs.Also(r.Managed.Validate(ctx).ViaField("managed"))
 	}
+	return errs
+}
+
+// managedVerbs are the verbs a managed ClusterRole may grant, it only lets the
+// ducks be read so that it cannot be used to escalate privileges.
+var managedVerbs = sets.NewString("get", "list", "watch")
+
+// builtInRoles are the user-facing ClusterRoles of Kubernetes, a managed
+// ClusterRole may not be aggregated into them nor into the system: roles.
+var builtInRoles = sets.NewString("admin", "edit", "view", "cluster-admin")
 
+// Validate implements apis.Validatable
+func (mr *ManagedRole) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if mr.AggregateTo == "" {
+		errs = errs.Also(apis.ErrMissingField("aggregateTo"))
+	} else if builtInRoles.Has(mr.AggregateTo) || strings.HasPrefix(mr.AggregateTo, "system:") {
+		errs = errs.Also(apis.ErrInvalidValue(mr.AggregateTo, "aggregateTo",
+			"a managed ClusterRole may not be aggregated into a built-in ClusterRole"))
+	} else if msgs := validation.IsQualifiedName(mr.AggregationLabel()); len(msgs) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(mr.AggregateTo, "aggregateTo", msgs...))
+	}
+	for i, verb := range mr.Verbs {
+		if !managedVerbs.Has(verb) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(verb, "verbs", i))
+		}
+	}
 	return errs
 }
 
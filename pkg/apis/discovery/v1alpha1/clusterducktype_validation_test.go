@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -263,6 +264,244 @@ func TestDuckTypeValidation(t *testing.T) {
 				Paths:   []string{"spec.selectors[0].labelSelector"},
 			},
 		},
+		"bad annotation selector and scope": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Selectors: []CustomResourceDefinitionSelector{{
+						LabelSelector:      "example.com/thisduck=true",
+						AnnotationSelector: "turn down for duck",
+						Scope:              "Pond",
+					}},
+				},
+			},
+			want: (&apis.FieldError{
+				Message: "invalid value: turn down for duck",
+				Paths:   []string{"spec.selectors[0].annotationSelector"},
+			}).Also(&apis.FieldError{
+				Message: "invalid value: Pond",
+				Paths:   []string{"spec.selectors[0].scope"},
+			}),
+		},
+		"bad exclude selector and selector mode": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Selectors: []CustomResourceDefinitionSelector{{
+						LabelSelector: "example.com/thisduck=true",
+					}},
+					ExcludeSelectors: []CustomResourceDefinitionSelector{{
+						LabelSelector: "turn down for duck",
+					}},
+					SelectorMode: "Any",
+				},
+			},
+			want: (&apis.FieldError{
+				Message: "invalid value: turn down for duck",
+				Paths:   []string{"spec.excludeSelectors[0].labelSelector"},
+			}).Also(&apis.FieldError{
+				Message: "invalid value: Any",
+				Paths:   []string{"spec.selectorMode"},
+			}),
+		},
+		"bad role subject": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Role: &Role{
+						Subject: &rbacv1.Subject{
+							Kind: rbacv1.UserKind,
+							Name: "duckie",
+						},
+					},
+				},
+			},
+			want: (&apis.FieldError{
+				Message: "invalid value: User",
+				Paths:   []string{"spec.role.subject.kind"},
+			}).Also(apis.ErrMissingField("spec.role.subject.namespace")),
+		},
+		"bad managed role": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Role: &Role{
+						Managed: &ManagedRole{
+							AggregateTo: "addressable resolver",
+							Verbs:       []string{"get", ""},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("addressable resolver", "spec.role.managed.aggregateTo",
+				"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')").
+				Also(apis.ErrInvalidArrayValue("", "spec.role.managed.verbs", 1)),
+		},
+		"managed role granting more than reads": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Role: &Role{
+						Managed: &ManagedRole{
+							AggregateTo: "addressable-resolver",
+							Verbs:       []string{"get", "create", "escalate"},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidArrayValue("create", "spec.role.managed.verbs", 1).
+				Also(apis.ErrInvalidArrayValue("escalate", "spec.role.managed.verbs", 2)),
+		},
+		"managed role aggregated into a built-in role": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Role: &Role{
+						Managed: &ManagedRole{
+							AggregateTo: "admin",
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("admin", "spec.role.managed.aggregateTo",
+				"a managed ClusterRole may not be aggregated into a built-in ClusterRole"),
+		},
+		"managed role aggregated into a system role": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Role: &Role{
+						Managed: &ManagedRole{
+							AggregateTo: "system:aggregate-to-view",
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("system:aggregate-to-view", "spec.role.managed.aggregateTo",
+				"a managed ClusterRole may not be aggregated into a built-in ClusterRole"),
+		},
+		"discover built-ins without a schema": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					DiscoverBuiltIns: true,
+				},
+			},
+			want: apis.ErrGeneric("a version with a schema is required to discover built-in kinds", "spec.discoverBuiltIns"),
+		},
+		"valid - role subject": {
+			in: &ClusterDuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "thisducks.example.com",
+				},
+				Spec: ClusterDuckTypeSpec{
+					Group: "example.com",
+					Names: DuckTypeNames{
+						Name:     "ThisDuck",
+						Plural:   "thisducks",
+						Singular: "thisduck",
+					},
+					Versions: []DuckVersion{{
+						Name: "v1",
+					}},
+					Role: &Role{
+						Subject: &rbacv1.Subject{
+							Kind:      rbacv1.ServiceAccountKind,
+							Name:      "controller",
+							Namespace: "knative-eventing",
+						},
+					},
+				},
+			},
+		},
 		"valid": {
 			in: &ClusterDuckType{
 				ObjectMeta: v1.ObjectMeta{
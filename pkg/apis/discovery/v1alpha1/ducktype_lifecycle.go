@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (*DuckType) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("DuckType")
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*DuckType) GetConditionSet() apis.ConditionSet {
+	return duckTypeCondSet
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (dts *DuckTypeStatus) InitializeConditions() {
+	duckTypeCondSet.Manage(dts).InitializeConditions()
+}
+
+// MarkCRDsDiscovered sets the CRDsDiscovered condition to true.
+func (dts *DuckTypeStatus) MarkCRDsDiscovered() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionCRDsDiscovered)
+}
+
+// MarkCRDsNotDiscovered sets the CRDsDiscovered condition to false with the
+// given reason and message.
+func (dts *DuckTypeStatus) MarkCRDsNotDiscovered(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionCRDsDiscovered, reason, messageFormat, messageA...)
+}
+
+// MarkRoleResolved sets the RoleResolved condition to true.
+func (dts *DuckTypeStatus) MarkRoleResolved() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionRoleResolved)
+}
+
+// MarkRoleResolvedWithReason sets the RoleResolved condition to true with the
+// given reason and message. It is used when there is no Role to resolve.
+func (dts *DuckTypeStatus) MarkRoleResolvedWithReason(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkTrueWithReason(DuckTypeConditionRoleResolved, reason, messageFormat, messageA...)
+}
+
+// MarkRoleUnresolved sets the RoleResolved condition to false with the given
+// reason and message.
+func (dts *DuckTypeStatus) MarkRoleUnresolved(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionRoleResolved, reason, messageFormat, messageA...)
+}
+
+// MarkRefsResolved sets the RefsResolved condition to true.
+func (dts *DuckTypeStatus) MarkRefsResolved() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionRefsResolved)
+}
+
+// MarkRefsResolvedWithReason sets the RefsResolved condition to true with the
+// given reason and message. It is used when refs were resolved differently
+// than they are described.
+func (dts *DuckTypeStatus) MarkRefsResolvedWithReason(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkTrueWithReason(DuckTypeConditionRefsResolved, reason, messageFormat, messageA...)
+}
+
+// MarkRefsUnresolved sets the RefsResolved condition to false with the given
+// reason and message.
+func (dts *DuckTypeStatus) MarkRefsUnresolved(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionRefsResolved, reason, messageFormat, messageA...)
+}
+
+// MarkDiscoveryHealthy sets the DiscoveryHealthy condition to true.
+func (dts *DuckTypeStatus) MarkDiscoveryHealthy() {
+	duckTypeCondSet.Manage(dts).MarkTrue(DuckTypeConditionDiscoveryHealthy)
+}
+
+// MarkDiscoveryUnhealthy sets the DiscoveryHealthy condition to false with the
+// given reason and message.
+func (dts *DuckTypeStatus) MarkDiscoveryUnhealthy(reason, messageFormat string, messageA ...interface{}) {
+	duckTypeCondSet.Manage(dts).MarkFalse(DuckTypeConditionDiscoveryHealthy, reason, messageFormat, messageA...)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DuckType is the namespaced form of ClusterDuckType. It only reports the
+// ducks that the users of its namespace are able to use.
+type DuckType struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the DuckType (from the client).
+	// +optional
+	Spec ClusterDuckTypeSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the DuckType (from the controller).
+	// +optional
+	Status DuckTypeStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that DuckType can be validated and defaulted.
+	_ apis.Validatable   = (*DuckType)(nil)
+	_ apis.Defaultable   = (*DuckType)(nil)
+	_ kmeta.OwnerRefable = (*DuckType)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*DuckType)(nil)
+)
+
+// DuckTypeStatus communicates the observed state of the DuckType (from the controller).
+type DuckTypeStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// Ducks is a versioned mapping of the found resources that implement this
+	// duck and are accessible from the namespace of the DuckType.
+	Ducks map[string][]ResourceMeta `json:"ducks,omitempty"`
+
+	// DuckCount is the count of unique duck types found post-hunt.
+	DuckCount int `json:"duckCount"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DuckTypeList is a list of DuckType resources
+type DuckTypeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DuckType `json:"items"`
+}
+
+// GetStatus retrieves the status of the resource. Implements the KRShaped interface.
+func (dt *DuckType) GetStatus() *duckv1.Status {
+	return &dt.Status.Status
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (dt *DuckType) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if dt.Name != fmt.Sprintf("%s.%s", dt.Spec.Names.Plural, dt.Spec.Group) {
+		errs = errs.Also(apis.ErrInvalidValue(dt.Name, "name"))
+	}
+
+	errs = errs.Also(dt.Spec.Validate(ctx).ViaField("spec"))
+
+	if dt.Spec.Role != nil && dt.Spec.Role.RoleRef != nil {
+		switch dt.Spec.Role.RoleRef.Kind {
+		case "Role", "ClusterRole":
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(dt.Spec.Role.RoleRef.Kind, "spec.role.roleRef.kind"))
+		}
+	}
+	// The subject uses the ducks from the namespace of the DuckType.
+	if dt.Spec.Role != nil && dt.Spec.Role.Subject != nil && dt.Spec.Role.Subject.Namespace != "" &&
+		dt.Spec.Role.Subject.Namespace != dt.Namespace {
+		errs = errs.Also(apis.ErrInvalidValue(dt.Spec.Role.Subject.Namespace, "spec.role.subject.namespace"))
+	}
+	// A namespaced DuckType cannot own a ClusterRole.
+	if dt.Spec.Role != nil && dt.Spec.Role.Managed != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("spec.role.managed"))
+	}
+	// Built-in kinds are only discovered for the whole cluster.
+	if dt.Spec.DiscoverBuiltIns {
+		errs = errs.Also(apis.ErrDisallowedFields("spec.discoverBuiltIns"))
+	}
+	// Instances are only counted for the whole cluster.
+	if dt.Spec.CountInstances {
+		errs = errs.Also(apis.ErrDisallowedFields("spec.countInstances"))
+	}
+	return errs
+}
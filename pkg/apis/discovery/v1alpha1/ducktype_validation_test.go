@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestNamespacedDuckTypeValidation(t *testing.T) {
+	spec := ClusterDuckTypeSpec{
+		Group: "example.com",
+		Names: DuckTypeNames{
+			Name:     "ThisDuck",
+			Plural:   "thisducks",
+			Singular: "thisduck",
+		},
+		Versions: []DuckVersion{{
+			Name: "v1",
+		}},
+	}
+
+	tests := map[string]struct {
+		in   *DuckType
+		want *apis.FieldError
+	}{
+		"empty": {
+			in: &DuckType{},
+			want: (&apis.FieldError{
+				Message: "invalid value: ",
+				Paths:   []string{"name"},
+			}).Also(
+				&apis.FieldError{
+					Message: "missing field(s)",
+					Paths:   []string{"spec.group", "spec.names.name", "spec.names.plural", "spec.names.singular", "spec.versions"},
+				}),
+		},
+		"valid": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: spec,
+			},
+		},
+		"invalid name": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thatducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: spec,
+			},
+			want: &apis.FieldError{
+				Message: "invalid value: thatducks.example.com",
+				Paths:   []string{"name"},
+			},
+		},
+		"role ref": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: func() ClusterDuckTypeSpec {
+					s := *spec.DeepCopy()
+					s.Role = &Role{RoleRef: &rbacv1.RoleRef{Kind: "Role", Name: "viewer"}}
+					return s
+				}(),
+			},
+		},
+		"bad role ref kind": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: func() ClusterDuckTypeSpec {
+					s := *spec.DeepCopy()
+					s.Role = &Role{RoleRef: &rbacv1.RoleRef{Kind: "Group", Name: "viewers"}}
+					return s
+				}(),
+			},
+			want: &apis.FieldError{
+				Message: "invalid value: Group",
+				Paths:   []string{"spec.role.roleRef.kind"},
+			},
+		},
+		"subject in the namespace": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: func() ClusterDuckTypeSpec {
+					s := *spec.DeepCopy()
+					s.Role = &Role{Subject: &rbacv1.Subject{Kind: "ServiceAccount", Name: "app", Namespace: "tenant"}}
+					return s
+				}(),
+			},
+		},
+		"subject in another namespace": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: func() ClusterDuckTypeSpec {
+					s := *spec.DeepCopy()
+					s.Role = &Role{Subject: &rbacv1.Subject{Kind: "ServiceAccount", Name: "app", Namespace: "kube-system"}}
+					return s
+				}(),
+			},
+			want: &apis.FieldError{
+				Message: "invalid value: kube-system",
+				Paths:   []string{"spec.role.subject.namespace"},
+			},
+		},
+		"managed role": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: func() ClusterDuckTypeSpec {
+					s := *spec.DeepCopy()
+					s.Role = &Role{Managed: &ManagedRole{AggregateTo: "viewer"}}
+					return s
+				}(),
+			},
+			want: apis.ErrDisallowedFields("spec.role.managed"),
+		},
+		"discover built-ins": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: func() ClusterDuckTypeSpec {
+					s := *spec.DeepCopy()
+					s.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {Type: "object"},
+							},
+						},
+					}
+					s.DiscoverBuiltIns = true
+					return s
+				}(),
+			},
+			want: apis.ErrDisallowedFields("spec.discoverBuiltIns"),
+		},
+		"count instances": {
+			in: &DuckType{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "thisducks.example.com",
+					Namespace: "tenant",
+				},
+				Spec: func() ClusterDuckTypeSpec {
+					s := *spec.DeepCopy()
+					s.CountInstances = true
+					return s
+				}(),
+			},
+			want: apis.ErrDisallowedFields("spec.countInstances"),
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := tc.in.Validate(context.Background())
+			if diff := cmp.Diff(tc.want.Error(), got.Error()); diff != "" {
+				t.Error("Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
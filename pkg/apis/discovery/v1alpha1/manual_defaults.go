@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+// SetDefaults implements apis.Defaultable
+func (m *Manual) SetDefaults(ctx context.Context) {
+	ctx = apis.WithinParent(ctx, m.ObjectMeta)
+	m.Spec.SetDefaults(apis.WithinSpec(ctx))
+}
+
+// SetDefaults implements apis.Defaultable
+func (ms *ManualSpec) SetDefaults(ctx context.Context) {
+	for v := range ms.Versions {
+		ms.Versions[v].SetDefaults(ctx)
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (mv *ManualVersion) SetDefaults(ctx context.Context) {
+	for f := range mv.Fields {
+		mv.Fields[f].SetDefaults(ctx)
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (fd *FieldDescription) SetDefaults(ctx context.Context) {
+	// Accept JSONPath style paths (`.spec.sink`) but store them without the
+	// leading dot.
+	fd.Path = strings.TrimPrefix(fd.Path, ".")
+}
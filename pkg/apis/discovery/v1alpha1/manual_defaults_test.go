@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestManualDefaulting(t *testing.T) {
+	tests := map[string]struct {
+		in   *Manual
+		want *Manual
+	}{
+		"empty": {
+			in:   &Manual{},
+			want: &Manual{},
+		},
+		"plain path": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Versions: []ManualVersion{{
+						Name:   "v1",
+						Fields: []FieldDescription{{Path: "spec.sink"}},
+					}},
+				}},
+			want: &Manual{
+				Spec: ManualSpec{
+					Versions: []ManualVersion{{
+						Name:   "v1",
+						Fields: []FieldDescription{{Path: "spec.sink"}},
+					}},
+				}},
+		},
+		"jsonpath style path": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Versions: []ManualVersion{{
+						Name:   "v1",
+						Fields: []FieldDescription{{Path: ".spec.sink"}},
+					}},
+				}},
+			want: &Manual{
+				Spec: ManualSpec{
+					Versions: []ManualVersion{{
+						Name:   "v1",
+						Fields: []FieldDescription{{Path: "spec.sink"}},
+					}},
+				}},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := tc.in
+			got.SetDefaults(context.Background())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("SetDefaults (-want, +got) =", diff)
+			}
+		})
+	}
+}
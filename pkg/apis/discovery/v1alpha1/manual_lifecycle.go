@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+var manualCondSet = apis.NewLivingConditionSet(
+	ManualConditionDuckTypesResolved,
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (*Manual) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Manual")
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*Manual) GetConditionSet() apis.ConditionSet {
+	return manualCondSet
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (ms *ManualStatus) InitializeConditions() {
+	manualCondSet.Manage(ms).InitializeConditions()
+}
+
+// MarkDuckTypesResolved sets the DuckTypesResolved condition to true.
+func (ms *ManualStatus) MarkDuckTypesResolved() {
+	manualCondSet.Manage(ms).MarkTrue(ManualConditionDuckTypesResolved)
+}
+
+// MarkDuckTypesUnresolved sets the DuckTypesResolved condition to false with
+// the given reason and message.
+func (ms *ManualStatus) MarkDuckTypesUnresolved(reason, messageFormat string, messageA ...interface{}) {
+	manualCondSet.Manage(ms).MarkFalse(ManualConditionDuckTypesResolved, reason, messageFormat, messageA...)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestManualDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{{
+		name: "conditions",
+		t:    &duckv1.Conditions{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&Manual{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(Manual, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
+func TestManualGetConditionSet(t *testing.T) {
+	r := &Manual{}
+
+	if got, want := r.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestManualGetGroupVersionKind(t *testing.T) {
+	r := &Manual{}
+	want := schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "Manual",
+	}
+	if got := r.GetGroupVersionKind(); got != want {
+		t.Errorf("GVK: %v, want: %v", got, want)
+	}
+}
+
+func TestManualInitializeConditions(t *testing.T) {
+	ms := &ManualStatus{}
+	ms.InitializeConditions()
+
+	types := make([]string, 0, len(ms.Conditions))
+	for _, cond := range ms.Conditions {
+		types = append(types, string(cond.Type))
+	}
+
+	// These are already sorted.
+	expected := []string{
+		string(ManualConditionDuckTypesResolved),
+		string(ManualConditionReady),
+	}
+
+	sort.Strings(types)
+
+	if diff := cmp.Diff(expected, types); diff != "" {
+		t.Error("Conditions(-want,+got):\n", diff)
+	}
+}
+
+func TestManualMarkDuckTypesResolved(t *testing.T) {
+	ms := &ManualStatus{}
+	ms.InitializeConditions()
+	ms.MarkDuckTypesResolved()
+
+	c := ms.GetCondition(ManualConditionReady)
+	if c == nil || c.Status != corev1.ConditionTrue {
+		t.Errorf("expected Ready to be true, got %v\n", c)
+	}
+}
+
+func TestManualMarkDuckTypesUnresolved(t *testing.T) {
+	ms := &ManualStatus{}
+	ms.InitializeConditions()
+	ms.MarkDuckTypesUnresolved("DuckTypeNotFound", "missing %s", "addressables.duck.knative.dev")
+
+	c := ms.GetCondition(ManualConditionReady)
+	if c == nil || c.Status != corev1.ConditionFalse {
+		t.Errorf("expected Ready to be false, got %v\n", c)
+	}
+	if got, want := c.Message, "missing addressables.duck.knative.dev"; got != want {
+		t.Errorf("expected message %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Manual is a strongly typed description of how to fill in the fields of a
+// resource that can be installed on a cluster.
+type Manual struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the Manual (from the client).
+	// +optional
+	Spec ManualSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the Manual (from the controller).
+	// +optional
+	Status ManualStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that Manual can be validated and defaulted.
+	_ apis.Validatable   = (*Manual)(nil)
+	_ apis.Defaultable   = (*Manual)(nil)
+	_ kmeta.OwnerRefable = (*Manual)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*Manual)(nil)
+)
+
+// ManualSpec holds the desired state of the Manual (from the client).
+type ManualSpec struct {
+	// Group is the API group of the described resource.
+	Group string `json:"group"`
+
+	// Kind is the CamelCased kind of the described resource.
+	Kind string `json:"kind"`
+
+	// Description is a human readable description of the described resource.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Install holds hints on where to find the package that installs the
+	// described resource on a cluster.
+	// +optional
+	Install []InstallHint `json:"install,omitempty"`
+
+	// Versions holds the field descriptions for specific versions of the
+	// described resource.
+	Versions []ManualVersion `json:"versions" patchStrategy:"merge" patchMergeKey:"name"`
+}
+
+// InstallHint points to a package that installs the described resource.
+type InstallHint struct {
+	// Description is a human readable description of the package.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// URL is where the package can be found.
+	URL *apis.URL `json:"url"`
+}
+
+// ManualVersion holds the field descriptions for a version of the described
+// resource.
+type ManualVersion struct {
+	// Name is the name of the version of the described resource.
+	Name string `json:"name"`
+
+	// Fields is a list of descriptions of how to fill in the fields of the
+	// described resource at this version.
+	// +optional
+	Fields []FieldDescription `json:"fields,omitempty" patchStrategy:"merge" patchMergeKey:"path"`
+}
+
+// FieldDescription explains how to fill in a single field of a resource.
+type FieldDescription struct {
+	// Path is the dot separated path to the field inside the described
+	// resource, for example `spec.subscriber.ref`.
+	Path string `json:"path"`
+
+	// Description is a human readable description of the field.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Required indicates the field must be filled in.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// Example is an example value for the field.
+	// +optional
+	Example string `json:"example,omitempty"`
+
+	// DuckType, if set, is the duck type the referenced resource must
+	// implement to be a valid value for this field.
+	// +optional
+	DuckType *DuckTypeReference `json:"duckType,omitempty"`
+}
+
+// DuckTypeReference points to a ClusterDuckType.
+type DuckTypeReference struct {
+	// Name is the name of the ClusterDuckType, in the form
+	// `<names.plural>.<group>`.
+	Name string `json:"name"`
+
+	// Version is the version of the duck type the field expects.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+const (
+	// ManualConditionReady is set when the Manual has been processed by the
+	// controller and all of its references are resolved.
+	ManualConditionReady = apis.ConditionReady
+
+	// ManualConditionDuckTypesResolved is set when every duck type referenced
+	// by the fields of the Manual is known to the cluster.
+	ManualConditionDuckTypesResolved apis.ConditionType = "DuckTypesResolved"
+)
+
+// ManualStatus communicates the observed state of the Manual (from the controller).
+type ManualStatus struct {
+	duckv1.Status `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ManualList is a list of Manual resources
+type ManualList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Manual `json:"items"`
+}
+
+// GetStatus retrieves the status of the resource. Implements the KRShaped interface.
+func (m *Manual) GetStatus() *duckv1.Status {
+	return &m.Status.Status
+}
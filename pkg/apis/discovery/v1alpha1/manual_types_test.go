@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestManualGetStatus(t *testing.T) {
+	status := &duckv1.Status{}
+	config := Manual{
+		Status: ManualStatus{
+			Status: *status,
+		},
+	}
+
+	if !cmp.Equal(config.GetStatus(), status) {
+		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", config.GetStatus(), status)
+	}
+}
+
+func TestManualRoundTrips_YAML(t *testing.T) {
+	y := `
+spec:
+  group: example.com
+  kind: Trigger
+  description: Subscribes to events from a broker.
+  versions:
+  - name: v1
+    fields:
+    - path: spec.subscriber.ref
+      description: The addressable that receives the events.
+      required: true
+      ducktype:
+        name: addressables.duck.knative.dev
+        version: v1
+`
+
+	want := &Manual{
+		Spec: ManualSpec{
+			Group:       "example.com",
+			Kind:        "Trigger",
+			Description: "Subscribes to events from a broker.",
+			Versions: []ManualVersion{{
+				Name: "v1",
+				Fields: []FieldDescription{{
+					Path:        "spec.subscriber.ref",
+					Description: "The addressable that receives the events.",
+					Required:    true,
+					DuckType: &DuckTypeReference{
+						Name:    "addressables.duck.knative.dev",
+						Version: "v1",
+					},
+				}},
+			}},
+		},
+	}
+
+	got := &Manual{}
+	if err := yaml.Unmarshal([]byte(y), got); err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("unexpected diff (-want, +got) =", diff)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (m *Manual) Validate(ctx context.Context) (errs *apis.FieldError) {
+	return m.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate implements apis.Validatable
+func (ms *ManualSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ms.Kind == "" {
+		errs = errs.Also(apis.ErrMissingField("kind"))
+	}
+	if len(ms.Versions) == 0 {
+		errs = errs.Also(apis.ErrMissingField("versions"))
+	}
+
+	for i, ih := range ms.Install {
+		errs = errs.Also(ih.Validate(ctx).ViaFieldIndex("install", i))
+	}
+
+	seenVersionNames := make(map[string]string)
+	for i, v := range ms.Versions {
+		if _, found := seenVersionNames[v.Name]; found {
+			errs = errs.Also((&apis.FieldError{
+				Message: fmt.Sprintf("duplicate entry found: %s", v.Name),
+				Paths:   []string{"name"},
+			}).ViaFieldIndex("versions", i))
+		}
+		seenVersionNames[v.Name] = v.Name
+		errs = errs.Also(v.Validate(ctx).ViaFieldIndex("versions", i))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (ih *InstallHint) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ih.URL == nil || ih.URL.IsEmpty() {
+		errs = errs.Also(apis.ErrMissingField("url"))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (mv *ManualVersion) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if mv.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
+	seenPaths := make(map[string]string)
+	for i, f := range mv.Fields {
+		if _, found := seenPaths[f.Path]; found {
+			errs = errs.Also((&apis.FieldError{
+				Message: fmt.Sprintf("duplicate entry found: %s", f.Path),
+				Paths:   []string{"path"},
+			}).ViaFieldIndex("fields", i))
+		}
+		seenPaths[f.Path] = f.Path
+		errs = errs.Also(f.Validate(ctx).ViaFieldIndex("fields", i))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (fd *FieldDescription) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if fd.Path == "" {
+		errs = errs.Also(apis.ErrMissingField("path"))
+	} else {
+		for _, segment := range strings.Split(fd.Path, ".") {
+			if segment == "" {
+				errs = errs.Also(apis.ErrInvalidValue(fd.Path, "path"))
+				break
+			}
+		}
+	}
+	if fd.DuckType != nil {
+		errs = errs.Also(fd.DuckType.Validate(ctx).ViaField("duckType"))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (dtr *DuckTypeReference) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if dtr.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if !strings.Contains(dtr.Name, ".") {
+		// ClusterDuckTypes are named in the form `<names.plural>.<group>`.
+		errs = errs.Also(apis.ErrInvalidValue(dtr.Name, "name"))
+	}
+	return errs
+}
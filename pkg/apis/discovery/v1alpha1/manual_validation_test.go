@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+)
+
+func TestManualValidation(t *testing.T) {
+	tests := map[string]struct {
+		in   *Manual
+		want *apis.FieldError
+	}{
+		"empty": {
+			in: &Manual{},
+			want: &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"spec.kind", "spec.versions"},
+			},
+		},
+		"valid": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Group: "example.com",
+					Kind:  "Trigger",
+					Install: []InstallHint{{
+						URL: apis.HTTPS("example.com"),
+					}},
+					Versions: []ManualVersion{{
+						Name: "v1",
+						Fields: []FieldDescription{{
+							Path: "spec.subscriber.ref",
+							DuckType: &DuckTypeReference{
+								Name: "addressables.duck.knative.dev",
+							},
+						}, {
+							Path: "spec.broker",
+						}},
+					}},
+				},
+			},
+		},
+		"core group": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind: "Pod",
+					Versions: []ManualVersion{{
+						Name: "v1",
+					}},
+				},
+			},
+		},
+		"duplicate version": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind:     "Trigger",
+					Versions: []ManualVersion{{Name: "v1"}, {Name: "v1"}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "duplicate entry found: v1",
+				Paths:   []string{"spec.versions[1].name"},
+			},
+		},
+		"missing version name": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind:     "Trigger",
+					Versions: []ManualVersion{{}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"spec.versions[0].name"},
+			},
+		},
+		"missing install url": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind:     "Trigger",
+					Install:  []InstallHint{{Description: "the release"}},
+					Versions: []ManualVersion{{Name: "v1"}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"spec.install[0].url"},
+			},
+		},
+		"duplicate field path": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind: "Trigger",
+					Versions: []ManualVersion{{
+						Name:   "v1",
+						Fields: []FieldDescription{{Path: "spec.broker"}, {Path: "spec.broker"}},
+					}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "duplicate entry found: spec.broker",
+				Paths:   []string{"spec.versions[0].fields[1].path"},
+			},
+		},
+		"missing field path": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind: "Trigger",
+					Versions: []ManualVersion{{
+						Name:   "v1",
+						Fields: []FieldDescription{{Description: "no path"}},
+					}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"spec.versions[0].fields[0].path"},
+			},
+		},
+		"invalid field path": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind: "Trigger",
+					Versions: []ManualVersion{{
+						Name:   "v1",
+						Fields: []FieldDescription{{Path: "spec..broker"}},
+					}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "invalid value: spec..broker",
+				Paths:   []string{"spec.versions[0].fields[0].path"},
+			},
+		},
+		"missing duck type name": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind: "Trigger",
+					Versions: []ManualVersion{{
+						Name: "v1",
+						Fields: []FieldDescription{{
+							Path:     "spec.subscriber.ref",
+							DuckType: &DuckTypeReference{Version: "v1"},
+						}},
+					}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"spec.versions[0].fields[0].duckType.name"},
+			},
+		},
+		"invalid duck type name": {
+			in: &Manual{
+				Spec: ManualSpec{
+					Kind: "Trigger",
+					Versions: []ManualVersion{{
+						Name: "v1",
+						Fields: []FieldDescription{{
+							Path:     "spec.subscriber.ref",
+							DuckType: &DuckTypeReference{Name: "addressables"},
+						}},
+					}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "invalid value: addressables",
+				Paths:   []string{"spec.versions[0].fields[0].duckType.name"},
+			},
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := tc.in.Validate(context.Background())
+			if diff := cmp.Diff(tc.want.Error(), got.Error()); diff != "" {
+				t.Error("Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
@@ -47,6 +47,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ClusterDuckType{},
 		&ClusterDuckTypeList{},
+		&DuckType{},
+		&DuckTypeList{},
+		&Manual{},
+		&ManualList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
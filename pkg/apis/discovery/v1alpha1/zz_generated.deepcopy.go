@@ -25,6 +25,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	apis "knative.dev/pkg/apis"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -102,7 +103,16 @@ func (in *ClusterDuckTypeSpec) DeepCopyInto(out *ClusterDuckTypeSpec) {
 	if in.Selectors != nil {
 		in, out := &in.Selectors, &out.Selectors
 		*out = make([]CustomResourceDefinitionSelector, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludeSelectors != nil {
+		in, out := &in.ExcludeSelectors, &out.ExcludeSelectors
+		*out = make([]CustomResourceDefinitionSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Role != nil {
 		in, out := &in.Role, &out.Role
@@ -136,11 +146,62 @@ func (in *ClusterDuckTypeStatus) DeepCopyInto(out *ClusterDuckTypeStatus) {
 			} else {
 				in, out := &val, &outVal
 				*out = make([]ResourceMeta, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.NonConformingDucks != nil {
+		in, out := &in.NonConformingDucks, &out.NonConformingDucks
+		*out = make(map[string][]NonConformingResourceMeta, len(*in))
+		for key, val := range *in {
+			var outVal []NonConformingResourceMeta
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]NonConformingResourceMeta, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.UnresolvedRefs != nil {
+		in, out := &in.UnresolvedRefs, &out.UnresolvedRefs
+		*out = make(map[string][]UnresolvedResourceRef, len(*in))
+		for key, val := range *in {
+			var outVal []UnresolvedResourceRef
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]UnresolvedResourceRef, len(*in))
 				copy(*out, *in)
 			}
 			(*out)[key] = outVal
 		}
 	}
+	if in.SkippedCRDs != nil {
+		in, out := &in.SkippedCRDs, &out.SkippedCRDs
+		*out = make([]SkippedCRD, len(*in))
+		copy(*out, *in)
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]InstanceCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ReadinessRollup)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ClusterRoleAggregationRule.DeepCopyInto(&out.ClusterRoleAggregationRule)
 	return
 }
@@ -158,6 +219,21 @@ func (in *ClusterDuckTypeStatus) DeepCopy() *ClusterDuckTypeStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomResourceDefinitionSelector) DeepCopyInto(out *CustomResourceDefinitionSelector) {
 	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -171,6 +247,67 @@ func (in *CustomResourceDefinitionSelector) DeepCopy() *CustomResourceDefinition
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuckType) DeepCopyInto(out *DuckType) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuckType.
+func (in *DuckType) DeepCopy() *DuckType {
+	if in == nil {
+		return nil
+	}
+	out := new(DuckType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DuckType) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuckTypeList) DeepCopyInto(out *DuckTypeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DuckType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuckTypeList.
+func (in *DuckTypeList) DeepCopy() *DuckTypeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DuckTypeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DuckTypeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DuckTypeNames) DeepCopyInto(out *DuckTypeNames) {
 	*out = *in
@@ -187,6 +324,56 @@ func (in *DuckTypeNames) DeepCopy() *DuckTypeNames {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuckTypeReference) DeepCopyInto(out *DuckTypeReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuckTypeReference.
+func (in *DuckTypeReference) DeepCopy() *DuckTypeReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DuckTypeReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuckTypeStatus) DeepCopyInto(out *DuckTypeStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Ducks != nil {
+		in, out := &in.Ducks, &out.Ducks
+		*out = make(map[string][]ResourceMeta, len(*in))
+		for key, val := range *in {
+			var outVal []ResourceMeta
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]ResourceMeta, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuckTypeStatus.
+func (in *DuckTypeStatus) DeepCopy() *DuckTypeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DuckTypeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DuckVersion) DeepCopyInto(out *DuckVersion) {
 	*out = *in
@@ -218,9 +405,305 @@ func (in *DuckVersion) DeepCopy() *DuckVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDescription) DeepCopyInto(out *FieldDescription) {
+	*out = *in
+	if in.DuckType != nil {
+		in, out := &in.DuckType, &out.DuckType
+		*out = new(DuckTypeReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDescription.
+func (in *FieldDescription) DeepCopy() *FieldDescription {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDescription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallHint) DeepCopyInto(out *InstallHint) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallHint.
+func (in *InstallHint) DeepCopy() *InstallHint {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallHint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceCount) DeepCopyInto(out *InstanceCount) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceCount.
+func (in *InstanceCount) DeepCopy() *InstanceCount {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedRole) DeepCopyInto(out *ManagedRole) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRole.
+func (in *ManagedRole) DeepCopy() *ManagedRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Manual) DeepCopyInto(out *Manual) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Manual.
+func (in *Manual) DeepCopy() *Manual {
+	if in == nil {
+		return nil
+	}
+	out := new(Manual)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Manual) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualList) DeepCopyInto(out *ManualList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Manual, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualList.
+func (in *ManualList) DeepCopy() *ManualList {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManualList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualSpec) DeepCopyInto(out *ManualSpec) {
+	*out = *in
+	if in.Install != nil {
+		in, out := &in.Install, &out.Install
+		*out = make([]InstallHint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ManualVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualSpec.
+func (in *ManualSpec) DeepCopy() *ManualSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualStatus) DeepCopyInto(out *ManualStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualStatus.
+func (in *ManualStatus) DeepCopy() *ManualStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualVersion) DeepCopyInto(out *ManualVersion) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]FieldDescription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualVersion.
+func (in *ManualVersion) DeepCopy() *ManualVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NonConformingResourceMeta) DeepCopyInto(out *NonConformingResourceMeta) {
+	*out = *in
+	in.ResourceMeta.DeepCopyInto(&out.ResourceMeta)
+	if in.MismatchedFields != nil {
+		in, out := &in.MismatchedFields, &out.MismatchedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NonConformingResourceMeta.
+func (in *NonConformingResourceMeta) DeepCopy() *NonConformingResourceMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(NonConformingResourceMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotReadyInstance) DeepCopyInto(out *NotReadyInstance) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotReadyInstance.
+func (in *NotReadyInstance) DeepCopy() *NotReadyInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(NotReadyInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessRollup) DeepCopyInto(out *ReadinessRollup) {
+	*out = *in
+	if in.WorstOffenders != nil {
+		in, out := &in.WorstOffenders, &out.WorstOffenders
+		*out = make([]NotReadyInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessRollup.
+func (in *ReadinessRollup) DeepCopy() *ReadinessRollup {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessRollup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceMeta) DeepCopyInto(out *ResourceMeta) {
 	*out = *in
+	if in.AccessibleBySubject != nil {
+		in, out := &in.AccessibleBySubject, &out.AccessibleBySubject
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -258,6 +741,16 @@ func (in *Role) DeepCopyInto(out *Role) {
 		*out = new(rbacv1.RoleRef)
 		**out = **in
 	}
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(rbacv1.Subject)
+		**out = **in
+	}
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(ManagedRole)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -270,3 +763,36 @@ func (in *Role) DeepCopy() *Role {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedCRD) DeepCopyInto(out *SkippedCRD) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedCRD.
+func (in *SkippedCRD) DeepCopy() *SkippedCRD {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedCRD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnresolvedResourceRef) DeepCopyInto(out *UnresolvedResourceRef) {
+	*out = *in
+	out.ResourceRef = in.ResourceRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnresolvedResourceRef.
+func (in *UnresolvedResourceRef) DeepCopy() *UnresolvedResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(UnresolvedResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible.
+// Converts source from v1beta1.ClusterDuckType into a higher version.
+func (source *ClusterDuckType) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1alpha1.ClusterDuckType:
+		sink.ObjectMeta = source.ObjectMeta
+		source.Spec.ConvertTo(ctx, &sink.Spec)
+		source.Status.ConvertTo(ctx, &sink.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertTo helps implement apis.Convertible for the spec.
+func (source *ClusterDuckTypeSpec) ConvertTo(ctx context.Context, sink *v1alpha1.ClusterDuckTypeSpec) {
+	sink.Group = source.Group
+	sink.Names = v1alpha1.DuckTypeNames(source.Names)
+	sink.Versions = nil
+	for _, dv := range source.Versions {
+		v := v1alpha1.DuckVersion{
+			Name:                     dv.Name,
+			AdditionalPrinterColumns: dv.AdditionalPrinterColumns,
+			Schema:                   dv.Schema,
+		}
+		for _, ref := range dv.Refs {
+			r := v1alpha1.ResourceRef{}
+			ref.ConvertTo(ctx, &r)
+			v.Refs = append(v.Refs, r)
+		}
+		sink.Versions = append(sink.Versions, v)
+	}
+	sink.Selectors = nil
+	for _, st := range source.Selectors {
+		var sel v1alpha1.CustomResourceDefinitionSelector
+		st.ConvertTo(ctx, &sel)
+		sink.Selectors = append(sink.Selectors, sel)
+	}
+	sink.ExcludeSelectors = nil
+	for _, st := range source.ExcludeSelectors {
+		var sel v1alpha1.CustomResourceDefinitionSelector
+		st.ConvertTo(ctx, &sel)
+		sink.ExcludeSelectors = append(sink.ExcludeSelectors, sel)
+	}
+	sink.SelectorMode = v1alpha1.SelectorMode(source.SelectorMode)
+	sink.Role = nil
+	if source.Role != nil {
+		sink.Role = &v1alpha1.Role{RoleRef: source.Role.RoleRef, Subject: source.Role.Subject}
+		if source.Role.Managed != nil {
+			sink.Role.Managed = &v1alpha1.ManagedRole{
+				AggregateTo: source.Role.Managed.AggregateTo,
+				Verbs:       source.Role.Managed.Verbs,
+			}
+		}
+	}
+	sink.DiscoverBuiltIns = source.DiscoverBuiltIns
+	sink.CountInstances = source.CountInstances
+}
+
+// ConvertTo helps implement apis.Convertible for the status.
+func (source *ClusterDuckTypeStatus) ConvertTo(ctx context.Context, sink *v1alpha1.ClusterDuckTypeStatus) {
+	sink.Status = source.Status
+	sink.Ducks = nil
+	if source.Ducks != nil {
+		sink.Ducks = make(map[string][]v1alpha1.ResourceMeta, len(source.Ducks))
+		for version, metas := range source.Ducks {
+			sms := make([]v1alpha1.ResourceMeta, len(metas))
+			for i := range metas {
+				metas[i].ConvertTo(ctx, &sms[i])
+			}
+			sink.Ducks[version] = sms
+		}
+	}
+	sink.DuckCount = source.DuckCount
+	sink.NonConformingDucks = nil
+	if source.NonConformingDucks != nil {
+		sink.NonConformingDucks = make(map[string][]v1alpha1.NonConformingResourceMeta, len(source.NonConformingDucks))
+		for version, metas := range source.NonConformingDucks {
+			sms := make([]v1alpha1.NonConformingResourceMeta, len(metas))
+			for i := range metas {
+				metas[i].ResourceMeta.ConvertTo(ctx, &sms[i].ResourceMeta)
+				sms[i].MismatchedFields = metas[i].MismatchedFields
+			}
+			sink.NonConformingDucks[version] = sms
+		}
+	}
+	sink.UnresolvedRefs = nil
+	if source.UnresolvedRefs != nil {
+		sink.UnresolvedRefs = make(map[string][]v1alpha1.UnresolvedResourceRef, len(source.UnresolvedRefs))
+		for version, refs := range source.UnresolvedRefs {
+			srs := make([]v1alpha1.UnresolvedResourceRef, len(refs))
+			for i := range refs {
+				refs[i].ResourceRef.ConvertTo(ctx, &srs[i].ResourceRef)
+				srs[i].Error = refs[i].Error
+			}
+			sink.UnresolvedRefs[version] = srs
+		}
+	}
+	sink.SkippedCRDs = nil
+	for _, sc := range source.SkippedCRDs {
+		sink.SkippedCRDs = append(sink.SkippedCRDs, v1alpha1.SkippedCRD(sc))
+	}
+	sink.Instances = nil
+	for _, ic := range source.Instances {
+		sink.Instances = append(sink.Instances, v1alpha1.InstanceCount(ic))
+	}
+	sink.Readiness = nil
+	if source.Readiness != nil {
+		sink.Readiness = &v1alpha1.ReadinessRollup{
+			Ready:    source.Readiness.Ready,
+			NotReady: source.Readiness.NotReady,
+			Unknown:  source.Readiness.Unknown,
+		}
+		for _, nri := range source.Readiness.WorstOffenders {
+			sink.Readiness.WorstOffenders = append(sink.Readiness.WorstOffenders, v1alpha1.NotReadyInstance(nri))
+		}
+	}
+	sink.ClusterRoleAggregationRule = rbacv1.AggregationRule{}
+	if source.ClusterRoleAggregationRule != nil {
+		sink.ClusterRoleAggregationRule = *source.ClusterRoleAggregationRule.DeepCopy()
+	}
+}
+
+// ConvertTo helps implement apis.Convertible for a resource meta.
+func (source *ResourceMeta) ConvertTo(ctx context.Context, sink *v1alpha1.ResourceMeta) {
+	sink.APIVersion = source.APIVersion
+	sink.Kind = source.Kind
+	sink.Resource = source.Resource
+	sink.Scope = v1alpha1.ResourceScope(source.Scope)
+	sink.AccessibleViaClusterRole = source.Access.ViaClusterRole
+	sink.AccessibleBySubject = source.Access.BySubject
+	sink.Storage = source.Storage
+	sink.Deprecated = source.Deprecated
+	sink.DeprecationWarning = source.DeprecationWarning
+	sink.Preferred = source.Preferred
+	sink.Source = v1alpha1.ResourceSource(source.Source)
+	sink.Verbs = source.Verbs
+	sink.Subresources = source.Subresources
+}
+
+// ConvertTo helps implement apis.Convertible for a CRD selector.
+func (source *CustomResourceDefinitionSelector) ConvertTo(ctx context.Context, sink *v1alpha1.CustomResourceDefinitionSelector) {
+	sink.LabelSelector = source.LabelSelector
+	sink.AnnotationSelector = source.AnnotationSelector
+	sink.Groups = source.Groups
+	sink.Scope = v1alpha1.ResourceScope(source.Scope)
+	sink.Kinds = source.Kinds
+	sink.Categories = source.Categories
+}
+
+// ConvertTo helps implement apis.Convertible for a resource ref.
+func (source *ResourceRef) ConvertTo(ctx context.Context, sink *v1alpha1.ResourceRef) {
+	sink.Group = source.Group
+	sink.Version = source.Version
+	sink.APIVersion = source.APIVersion
+	sink.Resource = source.Resource
+	sink.Kind = source.Kind
+	sink.Scope = v1alpha1.ResourceScope(source.Scope)
+}
+
+// ConvertFrom implements apis.Convertible.
+// Converts obj from a higher version into v1beta1.ClusterDuckType.
+func (sink *ClusterDuckType) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1alpha1.ClusterDuckType:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec.ConvertFrom(ctx, &source.Spec)
+		sink.Status.ConvertFrom(ctx, &source.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// ConvertFrom helps implement apis.Convertible for the spec.
+func (sink *ClusterDuckTypeSpec) ConvertFrom(ctx context.Context, source *v1alpha1.ClusterDuckTypeSpec) {
+	sink.Group = source.Group
+	sink.Names = DuckTypeNames(source.Names)
+	sink.Versions = nil
+	for _, dv := range source.Versions {
+		v := DuckVersion{
+			Name:                     dv.Name,
+			AdditionalPrinterColumns: dv.AdditionalPrinterColumns,
+			Schema:                   dv.Schema,
+		}
+		for _, ref := range dv.Refs {
+			r := ResourceRef{}
+			r.ConvertFrom(ctx, &ref)
+			v.Refs = append(v.Refs, r)
+		}
+		sink.Versions = append(sink.Versions, v)
+	}
+	sink.Selectors = nil
+	for _, st := range source.Selectors {
+		var sel CustomResourceDefinitionSelector
+		sel.ConvertFrom(ctx, &st)
+		sink.Selectors = append(sink.Selectors, sel)
+	}
+	sink.ExcludeSelectors = nil
+	for _, st := range source.ExcludeSelectors {
+		var sel CustomResourceDefinitionSelector
+		sel.ConvertFrom(ctx, &st)
+		sink.ExcludeSelectors = append(sink.ExcludeSelectors, sel)
+	}
+	sink.SelectorMode = SelectorMode(source.SelectorMode)
+	sink.Role = nil
+	if source.Role != nil {
+		sink.Role = &Role{RoleRef: source.Role.RoleRef, Subject: source.Role.Subject}
+		if source.Role.Managed != nil {
+			sink.Role.Managed = &ManagedRole{
+				AggregateTo: source.Role.Managed.AggregateTo,
+				Verbs:       source.Role.Managed.Verbs,
+			}
+		}
+	}
+	sink.DiscoverBuiltIns = source.DiscoverBuiltIns
+	sink.CountInstances = source.CountInstances
+}
+
+// ConvertFrom helps implement apis.Convertible for the status.
+func (sink *ClusterDuckTypeStatus) ConvertFrom(ctx context.Context, source *v1alpha1.ClusterDuckTypeStatus) {
+	sink.Status = source.Status
+	sink.Ducks = nil
+	if source.Ducks != nil {
+		sink.Ducks = make(map[string][]ResourceMeta, len(source.Ducks))
+		for version, metas := range source.Ducks {
+			sms := make([]ResourceMeta, len(metas))
+			for i := range metas {
+				sms[i].ConvertFrom(ctx, &metas[i])
+			}
+			sink.Ducks[version] = sms
+		}
+	}
+	sink.DuckCount = source.DuckCount
+	sink.NonConformingDucks = nil
+	if source.NonConformingDucks != nil {
+		sink.NonConformingDucks = make(map[string][]NonConformingResourceMeta, len(source.NonConformingDucks))
+		for version, metas := range source.NonConformingDucks {
+			sms := make([]NonConformingResourceMeta, len(metas))
+			for i := range metas {
+				sms[i].ResourceMeta.ConvertFrom(ctx, &metas[i].ResourceMeta)
+				sms[i].MismatchedFields = metas[i].MismatchedFields
+			}
+			sink.NonConformingDucks[version] = sms
+		}
+	}
+	sink.UnresolvedRefs = nil
+	if source.UnresolvedRefs != nil {
+		sink.UnresolvedRefs = make(map[string][]UnresolvedResourceRef, len(source.UnresolvedRefs))
+		for version, refs := range source.UnresolvedRefs {
+			srs := make([]UnresolvedResourceRef, len(refs))
+			for i := range refs {
+				srs[i].ResourceRef.ConvertFrom(ctx, &refs[i].ResourceRef)
+				srs[i].Error = refs[i].Error
+			}
+			sink.UnresolvedRefs[version] = srs
+		}
+	}
+	sink.SkippedCRDs = nil
+	for _, sc := range source.SkippedCRDs {
+		sink.SkippedCRDs = append(sink.SkippedCRDs, SkippedCRD(sc))
+	}
+	sink.Instances = nil
+	for _, ic := range source.Instances {
+		sink.Instances = append(sink.Instances, InstanceCount(ic))
+	}
+	sink.Readiness = nil
+	if source.Readiness != nil {
+		sink.Readiness = &ReadinessRollup{
+			Ready:    source.Readiness.Ready,
+			NotReady: source.Readiness.NotReady,
+			Unknown:  source.Readiness.Unknown,
+		}
+		for _, nri := range source.Readiness.WorstOffenders {
+			sink.Readiness.WorstOffenders = append(sink.Readiness.WorstOffenders, NotReadyInstance(nri))
+		}
+	}
+	sink.ClusterRoleAggregationRule = nil
+	if len(source.ClusterRoleAggregationRule.ClusterRoleSelectors) > 0 {
+		sink.ClusterRoleAggregationRule = source.ClusterRoleAggregationRule.DeepCopy()
+	}
+}
+
+// ConvertFrom helps implement apis.Convertible for a resource meta.
+func (sink *ResourceMeta) ConvertFrom(ctx context.Context, source *v1alpha1.ResourceMeta) {
+	sink.APIVersion = source.APIVersion
+	sink.Kind = source.Kind
+	sink.Resource = source.Resource
+	sink.Scope = ResourceScope(source.Scope)
+	sink.Access = ResourceAccess{
+		ViaClusterRole: source.AccessibleViaClusterRole,
+		BySubject:      source.AccessibleBySubject,
+	}
+	sink.Storage = source.Storage
+	sink.Deprecated = source.Deprecated
+	sink.DeprecationWarning = source.DeprecationWarning
+	sink.Preferred = source.Preferred
+	sink.Source = ResourceSource(source.Source)
+	sink.Verbs = source.Verbs
+	sink.Subresources = source.Subresources
+}
+
+// ConvertFrom helps implement apis.Convertible for a CRD selector.
+func (sink *CustomResourceDefinitionSelector) ConvertFrom(ctx context.Context, source *v1alpha1.CustomResourceDefinitionSelector) {
+	sink.LabelSelector = source.LabelSelector
+	sink.AnnotationSelector = source.AnnotationSelector
+	sink.Groups = source.Groups
+	sink.Scope = ResourceScope(source.Scope)
+	sink.Kinds = source.Kinds
+	sink.Categories = source.Categories
+}
+
+// ConvertFrom helps implement apis.Convertible for a resource ref.
+func (sink *ResourceRef) ConvertFrom(ctx context.Context, source *v1alpha1.ResourceRef) {
+	sink.Group = source.Group
+	sink.Version = source.Version
+	sink.APIVersion = source.APIVersion
+	sink.Resource = source.Resource
+	sink.Kind = source.Kind
+	sink.Scope = ResourceScope(source.Scope)
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func TestClusterDuckTypeConversionBadType(t *testing.T) {
+	good, bad := &ClusterDuckType{}, &testObject{}
+
+	if err := good.ConvertTo(context.Background(), bad); err == nil {
+		t.Errorf("ConvertTo() = %#v, wanted error", bad)
+	}
+
+	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+		t.Errorf("ConvertFrom() = %#v, wanted error", good)
+	}
+}
+
+func TestClusterDuckTypeConversionRoundTripV1beta1(t *testing.T) {
+	tests := map[string]*ClusterDuckType{
+		"empty": {},
+		"full": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "thisducks.example.com",
+				Generation: 2,
+			},
+			Spec: ClusterDuckTypeSpec{
+				Group: "example.com",
+				Names: DuckTypeNames{
+					Name:     "ThisDuck",
+					Plural:   "thisducks",
+					Singular: "thisduck",
+				},
+				Versions: []DuckVersion{{
+					Name: "v1",
+					Refs: []ResourceRef{{
+						Group:   "foo.com",
+						Version: "v2",
+						Kind:    "Bar",
+						Scope:   NamespaceScoped,
+					}},
+					AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{{
+						Name:     "URL",
+						Type:     "string",
+						JSONPath: ".status.address.url",
+					}},
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+					},
+				}},
+				Selectors: []CustomResourceDefinitionSelector{{
+					LabelSelector:      "example.com/thisduck=true",
+					AnnotationSelector: "example.com/vendor=acme",
+					Groups:             []string{"acme.example.com"},
+					Scope:              NamespaceScoped,
+					Kinds:              []string{"Pond"},
+					Categories:         []string{"water"},
+				}},
+				ExcludeSelectors: []CustomResourceDefinitionSelector{{
+					LabelSelector: "example.com/legacy=true",
+				}},
+				SelectorMode: IntersectionSelectorMode,
+				Role: &Role{
+					RoleRef: &rbacv1.RoleRef{
+						Kind: "ClusterRole",
+						Name: "thisduck-viewer",
+					},
+					Subject: &rbacv1.Subject{
+						Kind:      rbacv1.ServiceAccountKind,
+						Name:      "controller",
+						Namespace: "knative-eventing",
+					},
+					Managed: &ManagedRole{
+						AggregateTo: "addressable-resolver",
+						Verbs:       []string{"get", "list"},
+					},
+				},
+				DiscoverBuiltIns: true,
+				CountInstances:   true,
+			},
+			Status: ClusterDuckTypeStatus{
+				Status: duckv1.Status{
+					ObservedGeneration: 2,
+					Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionReady,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+				Ducks: map[string][]ResourceMeta{
+					"v1": {{
+						APIVersion: "foo.com/v2",
+						Kind:       "Bar",
+						Resource:   "bars",
+						Scope:      NamespaceScoped,
+						Access: ResourceAccess{
+							ViaClusterRole: true,
+							BySubject:      map[string]bool{"get": true, "list": true, "watch": false},
+						},
+						Storage:            true,
+						Deprecated:         true,
+						DeprecationWarning: "example.com/v2 Bar is deprecated",
+						Preferred:          true,
+					}, {
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Resource:   "deployments",
+						Scope:      NamespaceScoped,
+						Preferred:  true,
+						Source:     OpenAPISource,
+					}},
+				},
+				DuckCount: 2,
+				NonConformingDucks: map[string][]NonConformingResourceMeta{
+					"v1": {{
+						ResourceMeta: ResourceMeta{
+							APIVersion: "foo.com/v1",
+							Kind:       "Bar",
+							Resource:   "bars",
+							Scope:      NamespaceScoped,
+						},
+						MismatchedFields: []string{"status.address.url"},
+					}},
+				},
+				UnresolvedRefs: map[string][]UnresolvedResourceRef{
+					"v1": {{
+						ResourceRef: ResourceRef{
+							APIVersion: "foo.com/v1",
+							Kind:       "Baz",
+							Scope:      NamespaceScoped,
+						},
+						Error: `resource "Baz foo.com/v1" not known to the cluster`,
+					}},
+				},
+				SkippedCRDs: []SkippedCRD{{
+					Name:    "quxes.foo.com",
+					Reason:  "NotEstablished",
+					Message: "not all names are accepted",
+				}},
+				Instances: []InstanceCount{{
+					APIVersion: "foo.com/v1",
+					Kind:       "Bar",
+					Count:      3,
+					Namespaces: map[string]int{"default": 2, "other": 1},
+				}},
+				Readiness: &ReadinessRollup{
+					Ready:    2,
+					NotReady: 1,
+					WorstOffenders: []NotReadyInstance{{
+						APIVersion: "foo.com/v1",
+						Kind:       "Bar",
+						Namespace:  "default",
+						Name:       "broken",
+						Status:     corev1.ConditionFalse,
+						Reason:     "Broken",
+						Message:    "it broke",
+					}},
+				},
+				ClusterRoleAggregationRule: &rbacv1.AggregationRule{
+					ClusterRoleSelectors: []metav1.LabelSelector{{
+						MatchLabels: map[string]string{"example.com/thisduck": "true"},
+					}},
+				},
+			},
+		},
+	}
+
+	for n, in := range tests {
+		t.Run(n, func(t *testing.T) {
+			hub := &v1alpha1.ClusterDuckType{}
+			if err := in.ConvertTo(context.Background(), hub); err != nil {
+				t.Fatal("ConvertTo() =", err)
+			}
+
+			got := &ClusterDuckType{}
+			if err := got.ConvertFrom(context.Background(), hub); err != nil {
+				t.Fatal("ConvertFrom() =", err)
+			}
+
+			if diff := cmp.Diff(in, got); diff != "" {
+				t.Error("roundtrip (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestClusterDuckTypeConversionRoundTripV1alpha1(t *testing.T) {
+	tests := map[string]*v1alpha1.ClusterDuckType{
+		"empty": {},
+		"full": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "thisducks.example.com",
+			},
+			Spec: v1alpha1.ClusterDuckTypeSpec{
+				Group: "example.com",
+				Names: v1alpha1.DuckTypeNames{
+					Name:     "ThisDuck",
+					Plural:   "thisducks",
+					Singular: "thisduck",
+				},
+				Versions: []v1alpha1.DuckVersion{{
+					Name: "v1",
+					Refs: []v1alpha1.ResourceRef{{
+						APIVersion: "foo.com/v2",
+						Resource:   "bars",
+						Scope:      v1alpha1.ClusterScoped,
+					}},
+				}},
+			},
+			Status: v1alpha1.ClusterDuckTypeStatus{
+				Ducks: map[string][]v1alpha1.ResourceMeta{
+					"v1": {{
+						APIVersion: "foo.com/v2",
+						Kind:       "Bar",
+						Resource:   "bars",
+						Scope:      v1alpha1.ClusterScoped,
+					}, {
+						APIVersion:               "foo.com/v3",
+						Kind:                     "Bar",
+						Resource:                 "bars",
+						Scope:                    v1alpha1.ClusterScoped,
+						AccessibleViaClusterRole: true,
+						AccessibleBySubject:      map[string]bool{"get": false},
+						Verbs:                    []string{"get", "list", "watch"},
+						Subresources:             []string{"scale", "status"},
+					}},
+				},
+				DuckCount: 1,
+				NonConformingDucks: map[string][]v1alpha1.NonConformingResourceMeta{
+					"v1": {{
+						ResourceMeta: v1alpha1.ResourceMeta{
+							APIVersion: "foo.com/v1",
+							Kind:       "Bar",
+							Resource:   "bars",
+							Scope:      v1alpha1.ClusterScoped,
+						},
+						MismatchedFields: []string{"status.address", "status.conditions"},
+					}},
+				},
+				UnresolvedRefs: map[string][]v1alpha1.UnresolvedResourceRef{
+					"v1": {{
+						ResourceRef: v1alpha1.ResourceRef{
+							Group:    "foo.com",
+							Version:  "v1",
+							Resource: "bazs",
+							Scope:    v1alpha1.ClusterScoped,
+						},
+						Error: "kind not found for bazs in foo.com/v1",
+					}},
+				},
+				SkippedCRDs: []v1alpha1.SkippedCRD{{
+					Name:    "quxes.foo.com",
+					Reason:  "NotEstablished",
+					Message: "not all names are accepted",
+				}},
+				Instances: []v1alpha1.InstanceCount{{
+					APIVersion: "foo.com/v1",
+					Kind:       "Bar",
+					Count:      1,
+				}},
+				Readiness: &v1alpha1.ReadinessRollup{
+					Unknown: 1,
+					WorstOffenders: []v1alpha1.NotReadyInstance{{
+						APIVersion: "foo.com/v1",
+						Kind:       "Bar",
+						Name:       "pending",
+						Status:     corev1.ConditionUnknown,
+					}},
+				},
+			},
+		},
+	}
+
+	for n, in := range tests {
+		t.Run(n, func(t *testing.T) {
+			down := &ClusterDuckType{}
+			if err := down.ConvertFrom(context.Background(), in); err != nil {
+				t.Fatal("ConvertFrom() =", err)
+			}
+
+			got := &v1alpha1.ClusterDuckType{}
+			if err := down.ConvertTo(context.Background(), got); err != nil {
+				t.Fatal("ConvertTo() =", err)
+			}
+
+			if diff := cmp.Diff(in, got); diff != "" {
+				t.Error("roundtrip (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+type testObject struct{}
+
+func (*testObject) ConvertTo(context.Context, apis.Convertible) error {
+	return nil
+}
+
+func (*testObject) ConvertFrom(context.Context, apis.Convertible) error {
+	return nil
+}
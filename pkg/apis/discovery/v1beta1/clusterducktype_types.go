@@ -0,0 +1,562 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDuckType is a query and identifier for Knative-style duck types installed in a cluster.
+type ClusterDuckType struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the ClusterDuckType (from the client).
+	// +optional
+	Spec ClusterDuckTypeSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the ClusterDuckType (from the controller).
+	// +optional
+	Status ClusterDuckTypeStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that ClusterDuckType can be validated, defaulted and converted.
+	_ apis.Validatable   = (*ClusterDuckType)(nil)
+	_ apis.Defaultable   = (*ClusterDuckType)(nil)
+	_ apis.Convertible   = (*ClusterDuckType)(nil)
+	_ kmeta.OwnerRefable = (*ClusterDuckType)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*ClusterDuckType)(nil)
+)
+
+// ClusterDuckTypeSpec holds the desired state of the ClusterDuckType (from the client).
+// +k8s:openapi-gen=true
+type ClusterDuckTypeSpec struct {
+	// Group is the API group of the defined duck type.
+	// Must match the name of the ClusterDuckType (in the form `<names.plural>.<group>`).
+	Group string `json:"group"`
+
+	// Names holds the naming conventions for this duck type.
+	Names DuckTypeNames `json:"names"`
+
+	// Versions holds the schema and printer column mappings for specific
+	// versions for duck types.
+	Versions []DuckVersion `json:"versions" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Selectors is a list of selectors for CustomResourceDefinitions to identify a duck type.
+	// +optional
+	Selectors []CustomResourceDefinitionSelector `json:"selectors,omitempty"`
+
+	// ExcludeSelectors is a list of selectors for CustomResourceDefinitions
+	// that do not implement the duck type, even if Selectors match them.
+	// +optional
+	ExcludeSelectors []CustomResourceDefinitionSelector `json:"excludeSelectors,omitempty"`
+
+	// SelectorMode is how the CRDs matching each of the Selectors are
+	// combined. `Union` selects the CRDs matching any of the selectors and
+	// `Intersection` the CRDs matching all of them. Defaults to `Union`.
+	// +optional
+	SelectorMode SelectorMode `json:"selectorMode,omitempty"`
+
+	// Role holds an Aggregating Role used by the duck type to manage the ducks.
+	// If not specified, the Selectors are used to find a Role with an
+	// aggregation rule that matches a selector.
+	// +optional
+	Role *Role `json:"role,omitempty"`
+
+	// DiscoverBuiltIns matches the built-in and aggregated kinds served by
+	// the API server against the Schema of each duck version, using the
+	// OpenAPI v3 documents of the API server. Kinds that conform are added to
+	// the ducks. Only duck versions whose schema has properties are matched.
+	// +optional
+	DiscoverBuiltIns bool `json:"discoverBuiltIns,omitempty"`
+
+	// CountInstances has the controller count the instances of the ducks and
+	// sum up their readiness. Only the ducks the aggregating ClusterRole
+	// grants get, list and watch on, and that the controller can list, are
+	// counted.
+	// +optional
+	CountInstances bool `json:"countInstances,omitempty"`
+}
+
+// Role provides a way of specifying which Aggregating Role is used by the duck type to manage the ducks
+type Role struct {
+	// RoleRef is a reference to the Aggregating Role
+	// +optional
+	RoleRef *rbacv1.RoleRef `json:"roleRef,omitempty"`
+
+	// Subject is the ServiceAccount that uses the ducks. If set, the access
+	// of the subject to each duck is checked with SubjectAccessReviews.
+	// +optional
+	Subject *rbacv1.Subject `json:"subject,omitempty"`
+
+	// Managed has the reconciler maintain a ClusterRole that grants access to
+	// the ducks, labeled to be aggregated into another ClusterRole.
+	// +optional
+	Managed *ManagedRole `json:"managed,omitempty"`
+}
+
+// ManagedRole configures the ClusterRole maintained for the ducks of a duck
+// type. The ClusterRole is owned by the duck type and is named after it.
+type ManagedRole struct {
+	// AggregateTo is the name of the ClusterRole the managed ClusterRole is
+	// aggregated into. The managed ClusterRole is labeled
+	// `rbac.authorization.k8s.io/aggregate-to-<aggregateTo>: "true"`. The
+	// built-in admin, edit, view, cluster-admin and system: ClusterRoles are
+	// not allowed.
+	AggregateTo string `json:"aggregateTo"`
+
+	// Verbs are granted on every resource of the ducks, only get, list and
+	// watch are allowed. Defaults to get, list and watch.
+	// +optional
+	Verbs []string `json:"verbs,omitempty"`
+}
+
+// AggregationLabel returns the label that aggregates the managed ClusterRole
+// into the ClusterRole named by AggregateTo.
+func (mr *ManagedRole) AggregationLabel() string {
+	return "rbac.authorization.k8s.io/aggregate-to-" + mr.AggregateTo
+}
+
+// DuckTypeNames provides the naming rules for this duck type.
+type DuckTypeNames struct {
+	// Name is the serialized name of the resource. It is normally CamelCase and singular.
+	Name string `json:"name"`
+
+	// Plural is the plural name of the duck type.
+	// Must match the name of the ClusterDuckType (in the form `<names.plural>.<group>`).
+	// Must be all lowercase.
+	Plural string `json:"plural"`
+
+	// Singular is the singular name of the duck type. It must be all lowercase.
+	// Defaults to lowercased `name`.
+	Singular string `json:"singular"`
+}
+
+// DuckVersion holds the details of a version of the duck type.
+type DuckVersion struct {
+	// Name is the name of this duck type version.
+	Name string `json:"name"`
+
+	// Refs is a list of ResourceRefs that implement this duck type.
+	// Used for manual discovery.
+	// +optional
+	Refs []ResourceRef `json:"refs,omitempty"`
+
+	// Custom Columns to be used to pretty print the duck type at this version.
+	// +optional
+	AdditionalPrinterColumns []apiextensionsv1.CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty"`
+
+	// Partial Schema of this version of the duck type.
+	// +optional
+	Schema *apiextensionsv1.CustomResourceValidation `json:"schema,omitempty"`
+}
+
+// CustomResourceDefinitionSelector selects CustomResourceDefinitions that
+// implement the duck type. The CRDs matching LabelSelector are narrowed by
+// the other fields of the selector, a CRD must match all of them.
+type CustomResourceDefinitionSelector struct {
+	// LabelSelector is a label selector used to find CRDs that associate with
+	// the duck type.
+	// Typically this will be in the form:
+	//   `<group>/<names.singular>=true`
+	// Annotations are used to map the versions of the CRD to the correct
+	// ducktype. The annotation is expected to be in the form:
+	//   `<names.plural>.<group>/<versions[x].name>=[CRD.Version]`
+	// and results in `x = CRD.Version`.
+	// The duck type version annotation can have several CRD versions that map:
+	//   `<names.plural>.<group>/<versions[x].name>=[CRD.V1],[CRD.V2],[CRD.V3]`
+	// this tells the interrupter to match x to all of V1, V2 and V3 versions.
+	// If the version mapping annotation is missing, it is assumed this applies
+	// as the match.
+	// Must be a valid Kubernetes Label Selector.
+	LabelSelector string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
+
+	// AnnotationSelector narrows the CRDs matching LabelSelector to the CRDs
+	// with matching annotations. It uses the syntax of label selectors, for
+	// example `duck.knative.dev/vendor in (acme)`.
+	// +optional
+	AnnotationSelector string `json:"annotationSelector,omitempty" yaml:"annotationSelector,omitempty"`
+
+	// Groups narrows the matching CRDs to the CRDs of one of the API groups.
+	// +optional
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+
+	// Scope narrows the matching CRDs to the CRDs of the scope.
+	// +optional, allowed values are `Cluster` and `Namespaced`.
+	Scope ResourceScope `json:"scope,omitempty" yaml:"scope,omitempty"`
+
+	// Kinds narrows the matching CRDs to the CRDs of one of the kinds.
+	// +optional
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+
+	// Categories narrows the matching CRDs to the CRDs in at least one of the
+	// categories.
+	// +optional
+	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"`
+}
+
+// SelectorMode is an enum defining how the CRDs matching several selectors
+// are combined.
+type SelectorMode string
+
+const (
+	// UnionSelectorMode selects the CRDs matching any of the selectors.
+	UnionSelectorMode SelectorMode = "Union"
+	// IntersectionSelectorMode selects the CRDs matching all the selectors.
+	IntersectionSelectorMode SelectorMode = "Intersection"
+)
+
+// ResourceScope is an enum defining the different scopes available to a custom resource
+type ResourceScope string
+
+const (
+	ClusterScoped   ResourceScope = "Cluster"
+	NamespaceScoped ResourceScope = "Namespaced"
+)
+
+// ResourceSource is how a duck was discovered, when it was not through a CRD
+// or a ref.
+type ResourceSource string
+
+const (
+	// OpenAPISource marks the ducks matched against the OpenAPI v3 documents
+	// of the API server.
+	OpenAPISource ResourceSource = "OpenAPI"
+)
+
+// ResourceRef points to a Kubernetes Resource kind.
+type ResourceRef struct {
+	// Group is the resource group.
+	// +optional, must not be set if APIVersion is set.
+	Group string `json:"group,omitempty"`
+	// Version is the version the duck type applies to for the resource.
+	// +optional, one of [Version, APIVersion] required.
+	Version string `json:"version,omitempty"`
+
+	// APIVersion is the group and version of the resource combined.
+	// - if group is non-empty, `group/version`
+	// - if group is empty, `version`
+	// +optional, one of [Version, APIVersion] required.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Resource is the plural resource name.
+	// +optional, one of [Resource, Kind] required.
+	Resource string `json:"resource,omitempty"`
+	// Kind is the CamelCased resource kind.
+	// +optional, one of [Resource, Kind] required.
+	Kind string `json:"kind,omitempty"`
+
+	// Scope indicates whether the resource is cluster- or namespace-scoped.
+	// The ducks take the scope of the resource in the cluster, a scope that
+	// differs is reported.
+	// +optional, allowed values are `Cluster` and `Namespaced`.
+	Scope ResourceScope `json:"scope,omitempty"`
+}
+
+// GroupVersion puts "group" and "version" into a single "group/version" string
+// or returns APIVersion.
+func (r *ResourceRef) GroupVersion() string {
+	if len(r.APIVersion) > 0 {
+		return r.APIVersion
+	}
+	if len(r.Group) > 0 {
+		return r.Group + "/" + r.Version
+	}
+	return r.Version
+}
+
+// ResourceMeta is a resolved ResourceRef.
+type ResourceMeta struct {
+	// APIVersion is the group and version of the resource combined.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind is the CamelCased resource kind.
+	Kind string `json:"kind"`
+
+	// Resource is the plural resource name.
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
+	// Scope indicates whether the resource is cluster- or namespace-scoped.
+	// Allowed values are `Cluster` and `Namespaced`.
+	Scope ResourceScope `json:"scope"`
+
+	// Access describes how the resource can be accessed.
+	// +optional
+	Access ResourceAccess `json:"access,omitempty"`
+
+	// Storage indicates the version is the storage version of the CRD.
+	// +optional
+	Storage bool `json:"storage,omitempty"`
+
+	// Deprecated indicates the version of the CRD is deprecated.
+	// +optional
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// DeprecationWarning is the warning returned to API clients of a
+	// deprecated version of the CRD.
+	// +optional
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
+
+	// Preferred indicates the version is the one to use for the kind among the
+	// versions of the same duck version.
+	// +optional
+	Preferred bool `json:"preferred,omitempty"`
+
+	// Source is how the resource was discovered. It is empty for resources
+	// found through CRDs and refs.
+	// +optional
+	Source ResourceSource `json:"source,omitempty"`
+
+	// Verbs are the verbs the API server supports on the resource, as listed
+	// by discovery.
+	// +optional
+	Verbs []string `json:"verbs,omitempty"`
+
+	// Subresources are the `status` and `scale` subresources served for the
+	// resource, as listed by discovery.
+	// +optional
+	Subresources []string `json:"subresources,omitempty"`
+}
+
+// ResourceAccess describes how a resource can be accessed.
+type ResourceAccess struct {
+	// ViaClusterRole indicates whether the Role of the ClusterDuckType can
+	// perform get, list and watch on the resource.
+	// +optional
+	ViaClusterRole bool `json:"viaClusterRole,omitempty"`
+
+	// BySubject holds, for each verb checked, whether the Subject of the Role
+	// of the ClusterDuckType can perform it on the resource.
+	// +optional
+	BySubject map[string]bool `json:"bySubject,omitempty"`
+}
+
+// Version inspects a ResourceMeta object and returns the correct version
+// based on APIVersion.
+func (r *ResourceMeta) Version() string {
+	if strings.Contains(r.APIVersion, "/") {
+		sp := strings.Split(r.APIVersion, "/")
+		return sp[len(sp)-1]
+	}
+	return r.APIVersion
+}
+
+// Group inspects a ResourceMeta object and returns the correct group
+// based on APIVersion.
+func (r *ResourceMeta) Group() string {
+	if strings.Contains(r.APIVersion, "/") {
+		sp := strings.Split(r.APIVersion, "/")
+		return sp[0]
+	}
+	return ""
+}
+
+// NonConformingResourceMeta is a ResourceMeta of a resource that was selected
+// for the duck type, but does not satisfy the schema of the duck version.
+type NonConformingResourceMeta struct {
+	ResourceMeta `json:",inline"`
+
+	// MismatchedFields holds the paths of the fields of the duck version
+	// schema that the schema of the resource does not satisfy.
+	MismatchedFields []string `json:"mismatchedFields"`
+}
+
+// UnresolvedResourceRef is a ResourceRef of a duck version that could not be
+// resolved to a resource known to the cluster.
+type UnresolvedResourceRef struct {
+	ResourceRef `json:",inline"`
+
+	// Error is the reason the ref could not be resolved.
+	Error string `json:"error"`
+}
+
+// SkippedCRD is a CRD selected for the duck type that was not hunted for ducks
+// because the API server does not serve it as it is described.
+type SkippedCRD struct {
+	// Name is the name of the CRD.
+	Name string `json:"name"`
+	// Reason is a one-word CamelCase reason the CRD was skipped.
+	Reason string `json:"reason"`
+	// Message is a human readable explanation of why the CRD was skipped.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// InstanceCount is the number of instances of a kind of duck on the cluster.
+type InstanceCount struct {
+	// APIVersion is the version of the resource that was watched to count the
+	// instances.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Count is the total number of instances.
+	Count int `json:"count"`
+
+	// Namespaces maps namespaces to the number of instances in them.
+	// Instances of cluster scoped resources are only part of Count.
+	// +optional
+	Namespaces map[string]int `json:"namespaces,omitempty"`
+}
+
+// ReadinessRollup sums up the Ready condition of the instances of the ducks.
+type ReadinessRollup struct {
+	// Ready is the number of instances that are ready.
+	Ready int `json:"ready"`
+	// NotReady is the number of instances that are not ready.
+	NotReady int `json:"notReady"`
+	// Unknown is the number of instances without a Ready condition, with an
+	// unknown Ready condition, or with a status that is older than their spec.
+	Unknown int `json:"unknown"`
+
+	// WorstOffenders lists the instances that are not ready first, and then
+	// the instances whose readiness is unknown, each ordered by how long they
+	// have been in that state. The list is capped at ten instances.
+	// +optional
+	WorstOffenders []NotReadyInstance `json:"worstOffenders,omitempty"`
+}
+
+// NotReadyInstance is an instance of a duck that is not ready.
+type NotReadyInstance struct {
+	// APIVersion is the version of the resource of the instance.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource of the instance.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the instance, empty for cluster scoped
+	// resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the instance.
+	Name string `json:"name"`
+
+	// Status is the status of the Ready condition of the instance, False or
+	// Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Reason is the reason of the Ready condition of the instance.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is the message of the Ready condition of the instance.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the Ready condition of the instance
+	// changed.
+	// +optional
+	LastTransitionTime apis.VolatileTime `json:"lastTransitionTime,omitempty"`
+}
+
+const (
+	// DuckTypeConditionReady is set when the duck type has been processed by
+	// the controller.
+	DuckTypeConditionReady = apis.ConditionReady
+
+	// DuckTypeConditionCRDsDiscovered is set when the CustomResourceDefinitions
+	// matching the selectors of the duck type could be listed.
+	DuckTypeConditionCRDsDiscovered apis.ConditionType = "CRDsDiscovered"
+
+	// DuckTypeConditionRoleResolved is set when the Role used to decide which
+	// ducks are accessible could be resolved.
+	DuckTypeConditionRoleResolved apis.ConditionType = "RoleResolved"
+
+	// DuckTypeConditionRefsResolved is set when every ref of the duck type
+	// versions is known to the cluster.
+	DuckTypeConditionRefsResolved apis.ConditionType = "RefsResolved"
+
+	// DuckTypeConditionDiscoveryHealthy is set when every group version served
+	// by the cluster could be discovered. It does not affect readiness, the
+	// ducks of the group versions that were discovered are kept.
+	DuckTypeConditionDiscoveryHealthy apis.ConditionType = "DiscoveryHealthy"
+
+	// DuckTypeConditionInstancesCounted is set when the instances of every
+	// duck are counted. It is only set if the instances are counted, and does
+	// not affect readiness.
+	DuckTypeConditionInstancesCounted apis.ConditionType = "InstancesCounted"
+)
+
+// ClusterDuckTypeStatus communicates the observed state of the ClusterDuckType (from the controller).
+type ClusterDuckTypeStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// Ducks is a versioned mapping of the found resources that implement this duck.
+	// +optional
+	Ducks map[string][]ResourceMeta `json:"ducks,omitempty"`
+
+	// DuckCount is the count of unique duck types found post-hunt.
+	DuckCount int `json:"duckCount"`
+
+	// NonConformingDucks is a versioned mapping of the found resources that
+	// were selected for this duck, but do not satisfy the schema of the duck
+	// version. These are not part of Ducks.
+	// +optional
+	NonConformingDucks map[string][]NonConformingResourceMeta `json:"nonConformingDucks,omitempty"`
+
+	// UnresolvedRefs is a versioned mapping of the refs of the duck versions
+	// that could not be resolved. These are not part of Ducks.
+	// +optional
+	UnresolvedRefs map[string][]UnresolvedResourceRef `json:"unresolvedRefs,omitempty"`
+
+	// SkippedCRDs lists the selected CRDs that are not established, or whose
+	// names were not accepted, ordered by name. These are not part of Ducks.
+	// +optional
+	SkippedCRDs []SkippedCRD `json:"skippedCRDs,omitempty"`
+
+	// Instances holds the number of instances of each kind of duck found
+	// post-hunt, ordered by API version and kind. Kinds whose instances are
+	// not known yet are left out.
+	// +optional
+	Instances []InstanceCount `json:"instances,omitempty"`
+
+	// Readiness sums up the Ready condition of the instances counted in
+	// Instances.
+	// +optional
+	Readiness *ReadinessRollup `json:"readiness,omitempty"`
+
+	// ClusterRoleAggregationRule is the aggregation rule of the Role used to
+	// decide which ducks are accessible.
+	// +optional
+	ClusterRoleAggregationRule *rbacv1.AggregationRule `json:"clusterRoleAggregationRule,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDuckTypeList is a list of ClusterDuckType resources
+type ClusterDuckTypeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterDuckType `json:"items"`
+}
+
+// GetStatus retrieves the status of the resource. Implements the KRShaped interface.
+func (dt *ClusterDuckType) GetStatus() *duckv1.Status {
+	return &dt.Status.Status
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestClusterDuckTypeGetStatus(t *testing.T) {
+	status := &duckv1.Status{}
+	config := ClusterDuckType{
+		Status: ClusterDuckTypeStatus{
+			Status: *status,
+		},
+	}
+
+	if !cmp.Equal(config.GetStatus(), status) {
+		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", config.GetStatus(), status)
+	}
+}
+
+func TestResourceMeta_GroupVersion(t *testing.T) {
+	tests := map[string]struct {
+		meta        ResourceMeta
+		wantGroup   string
+		wantVersion string
+	}{
+		"with group": {
+			meta:        ResourceMeta{APIVersion: "example.com/v1"},
+			wantGroup:   "example.com",
+			wantVersion: "v1",
+		},
+		"core": {
+			meta:        ResourceMeta{APIVersion: "v1"},
+			wantGroup:   "",
+			wantVersion: "v1",
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := tc.meta.Group(); got != tc.wantGroup {
+				t.Errorf("Group() = %q, want %q", got, tc.wantGroup)
+			}
+			if got := tc.meta.Version(); got != tc.wantVersion {
+				t.Errorf("Version() = %q, want %q", got, tc.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (dt *ClusterDuckType) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if dt.Name != fmt.Sprintf("%s.%s", dt.Spec.Names.Plural, dt.Spec.Group) {
+		errs = errs.Also(apis.ErrInvalidValue(dt.Name, "name"))
+	}
+
+	return errs.Also(dt.Spec.Validate(ctx).ViaField("spec"))
+}
+
+// Validate implements apis.Validatable
+func (dts *ClusterDuckTypeSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if dts.Group == "" {
+		errs = errs.Also(apis.ErrMissingField("group"))
+	}
+	if len(dts.Versions) == 0 {
+		errs = errs.Also(apis.ErrMissingField("versions"))
+	}
+
+	errs = errs.Also(dts.Names.Validate(ctx).ViaField("names"))
+
+	seenVersionNames := make(map[string]string)
+	for i, v := range dts.Versions {
+		if _, found := seenVersionNames[v.Name]; found {
+			errs = errs.Also((&apis.FieldError{
+				Message: fmt.Sprintf("duplicate entry found: %s", v.Name),
+				Paths:   []string{"name"},
+			}).ViaFieldIndex("versions", i))
+		}
+		seenVersionNames[v.Name] = v.Name
+		errs = errs.Also(v.Validate(ctx).ViaFieldIndex("versions", i))
+	}
+
+	for i, st := range dts.Selectors {
+		errs = errs.Also(st.Validate(ctx).ViaFieldIndex("selectors", i))
+	}
+	for i, st := range dts.ExcludeSelectors {
+		errs = errs.Also(st.Validate(ctx).ViaFieldIndex("excludeSelectors", i))
+	}
+	switch dts.SelectorMode {
+	case "", UnionSelectorMode, IntersectionSelectorMode:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(dts.SelectorMode, "selectorMode"))
+	}
+	if dts.Role != nil {
+		errs = errs.Also(dts.Role.Validate(ctx).ViaField("role"))
+	}
+	if dts.DiscoverBuiltIns && !hasSchemaProperties(dts.Versions) {
+		errs = errs.Also(apis.ErrGeneric("a version with a schema is required to discover built-in kinds", "discoverBuiltIns"))
+	}
+
+	return errs
+}
+
+// hasSchemaProperties returns true if one of the versions has a schema with
+// properties to match kinds against.
+func hasSchemaProperties(versions []DuckVersion) bool {
+	for _, v := range versions {
+		if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil && len(v.Schema.OpenAPIV3Schema.Properties) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate implements apis.Validatable
+func (st *CustomResourceDefinitionSelector) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if _, err := labels.Parse(st.LabelSelector); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(st.LabelSelector, "labelSelector"))
+	}
+	if _, err := labels.Parse(st.AnnotationSelector); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(st.AnnotationSelector, "annotationSelector"))
+	}
+	switch st.Scope {
+	case "", ClusterScoped, NamespaceScoped:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(st.Scope, "scope"))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (r *Role) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Subject != nil {
+		var serrs *apis.FieldError
+		if r.Subject.Kind != rbacv1.ServiceAccountKind {
+			serrs = serrs.Also(apis.ErrInvalidValue(r.Subject.Kind, "kind"))
+		}
+		if r.Subject.Name == "" {
+			serrs = serrs.Also(apis.ErrMissingField("name"))
+		}
+		if r.Subject.Namespace == "" {
+			serrs = serrs.Also(apis.ErrMissingField("namespace"))
+		}
+		errs = errs.Also(serrs.ViaField("subject"))
+	}
+	if r.Managed != nil {
+		errs = errs.Also(r.Managed.Validate(ctx).ViaField("managed"))
+	}
+	return errs
+}
+
+// managedVerbs are the verbs a managed ClusterRole may grant, it only lets the
+// ducks be read so that it cannot be used to escalate privileges.
+var managedVerbs = sets.NewString("get", "list", "watch")
+
+// builtInRoles are the user-facing ClusterRoles of Kubernetes, a managed
+// ClusterRole may not be aggregated into them nor into the system: roles.
+var builtInRoles = sets.NewString("admin", "edit", "view", "cluster-admin")
+
+// Validate implements apis.Validatable
+func (mr *ManagedRole) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if mr.AggregateTo == "" {
+		errs = errs.Also(apis.ErrMissingField("aggregateTo"))
+	} else if builtInRoles.Has(mr.AggregateTo) || strings.HasPrefix(mr.AggregateTo, "system:") {
+		errs = errs.Also(apis.ErrInvalidValue(mr.AggregateTo, "aggregateTo",
+			"a managed ClusterRole may not be aggregated into a built-in ClusterRole"))
+	} else if msgs := validation.IsQualifiedName(mr.AggregationLabel()); len(msgs) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(mr.AggregateTo, "aggregateTo", msgs...))
+	}
+	for i, verb := range mr.Verbs {
+		if !managedVerbs.Has(verb) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(verb, "verbs", i))
+		}
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (dtn *DuckTypeNames) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if dtn.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if dtn.Plural == "" {
+		errs = errs.Also(apis.ErrMissingField("plural"))
+	} else if dtn.Plural != strings.ToLower(dtn.Plural) {
+		errs = errs.Also(apis.ErrInvalidValue(dtn.Plural, "plural"))
+	}
+	if dtn.Singular == "" {
+		errs = errs.Also(apis.ErrMissingField("singular"))
+	} else if dtn.Singular != strings.ToLower(dtn.Singular) {
+		errs = errs.Also(apis.ErrInvalidValue(dtn.Singular, "singular"))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (dv *DuckVersion) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if dv.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	for i, ref := range dv.Refs {
+		errs = errs.Also(ref.Validate(ctx).ViaFieldIndex("refs", i))
+	}
+	return errs
+}
+
+// Validate implements apis.Validatable
+func (g *ResourceRef) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// Version OR APIVersion
+	if g.Version != "" && g.APIVersion != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("version", "apiVersion"))
+	} else if g.Version == "" && g.APIVersion == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("version", "apiVersion"))
+	}
+
+	// Kind OR Resource
+	if g.Kind != "" && g.Resource != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("kind", "resource"))
+	} else if g.Kind == "" && g.Resource == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("kind", "resource"))
+	}
+
+	// If Group, then APIVersion should not be set.
+	if g.Group != "" && g.APIVersion != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("group", "apiVersion"))
+	}
+	return errs
+}
@@ -0,0 +1,534 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDuckType) DeepCopyInto(out *ClusterDuckType) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDuckType.
+func (in *ClusterDuckType) DeepCopy() *ClusterDuckType {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDuckType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDuckType) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDuckTypeList) DeepCopyInto(out *ClusterDuckTypeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDuckType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDuckTypeList.
+func (in *ClusterDuckTypeList) DeepCopy() *ClusterDuckTypeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDuckTypeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDuckTypeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDuckTypeSpec) DeepCopyInto(out *ClusterDuckTypeSpec) {
+	*out = *in
+	out.Names = in.Names
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]DuckVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]CustomResourceDefinitionSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludeSelectors != nil {
+		in, out := &in.ExcludeSelectors, &out.ExcludeSelectors
+		*out = make([]CustomResourceDefinitionSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(Role)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDuckTypeSpec.
+func (in *ClusterDuckTypeSpec) DeepCopy() *ClusterDuckTypeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDuckTypeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDuckTypeStatus) DeepCopyInto(out *ClusterDuckTypeStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Ducks != nil {
+		in, out := &in.Ducks, &out.Ducks
+		*out = make(map[string][]ResourceMeta, len(*in))
+		for key, val := range *in {
+			var outVal []ResourceMeta
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]ResourceMeta, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.NonConformingDucks != nil {
+		in, out := &in.NonConformingDucks, &out.NonConformingDucks
+		*out = make(map[string][]NonConformingResourceMeta, len(*in))
+		for key, val := range *in {
+			var outVal []NonConformingResourceMeta
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]NonConformingResourceMeta, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.UnresolvedRefs != nil {
+		in, out := &in.UnresolvedRefs, &out.UnresolvedRefs
+		*out = make(map[string][]UnresolvedResourceRef, len(*in))
+		for key, val := range *in {
+			var outVal []UnresolvedResourceRef
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]UnresolvedResourceRef, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.SkippedCRDs != nil {
+		in, out := &in.SkippedCRDs, &out.SkippedCRDs
+		*out = make([]SkippedCRD, len(*in))
+		copy(*out, *in)
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]InstanceCount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ReadinessRollup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterRoleAggregationRule != nil {
+		in, out := &in.ClusterRoleAggregationRule, &out.ClusterRoleAggregationRule
+		*out = new(v1.AggregationRule)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDuckTypeStatus.
+func (in *ClusterDuckTypeStatus) DeepCopy() *ClusterDuckTypeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDuckTypeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceDefinitionSelector) DeepCopyInto(out *CustomResourceDefinitionSelector) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceDefinitionSelector.
+func (in *CustomResourceDefinitionSelector) DeepCopy() *CustomResourceDefinitionSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomResourceDefinitionSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuckTypeNames) DeepCopyInto(out *DuckTypeNames) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuckTypeNames.
+func (in *DuckTypeNames) DeepCopy() *DuckTypeNames {
+	if in == nil {
+		return nil
+	}
+	out := new(DuckTypeNames)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DuckVersion) DeepCopyInto(out *DuckVersion) {
+	*out = *in
+	if in.Refs != nil {
+		in, out := &in.Refs, &out.Refs
+		*out = make([]ResourceRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalPrinterColumns != nil {
+		in, out := &in.AdditionalPrinterColumns, &out.AdditionalPrinterColumns
+		*out = make([]apiextensionsv1.CustomResourceColumnDefinition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensionsv1.CustomResourceValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DuckVersion.
+func (in *DuckVersion) DeepCopy() *DuckVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(DuckVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceCount) DeepCopyInto(out *InstanceCount) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceCount.
+func (in *InstanceCount) DeepCopy() *InstanceCount {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedRole) DeepCopyInto(out *ManagedRole) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRole.
+func (in *ManagedRole) DeepCopy() *ManagedRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NonConformingResourceMeta) DeepCopyInto(out *NonConformingResourceMeta) {
+	*out = *in
+	in.ResourceMeta.DeepCopyInto(&out.ResourceMeta)
+	if in.MismatchedFields != nil {
+		in, out := &in.MismatchedFields, &out.MismatchedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NonConformingResourceMeta.
+func (in *NonConformingResourceMeta) DeepCopy() *NonConformingResourceMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(NonConformingResourceMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotReadyInstance) DeepCopyInto(out *NotReadyInstance) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotReadyInstance.
+func (in *NotReadyInstance) DeepCopy() *NotReadyInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(NotReadyInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessRollup) DeepCopyInto(out *ReadinessRollup) {
+	*out = *in
+	if in.WorstOffenders != nil {
+		in, out := &in.WorstOffenders, &out.WorstOffenders
+		*out = make([]NotReadyInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessRollup.
+func (in *ReadinessRollup) DeepCopy() *ReadinessRollup {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessRollup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceAccess) DeepCopyInto(out *ResourceAccess) {
+	*out = *in
+	if in.BySubject != nil {
+		in, out := &in.BySubject, &out.BySubject
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceAccess.
+func (in *ResourceAccess) DeepCopy() *ResourceAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMeta) DeepCopyInto(out *ResourceMeta) {
+	*out = *in
+	in.Access.DeepCopyInto(&out.Access)
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMeta.
+func (in *ResourceMeta) DeepCopy() *ResourceMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
+func (in *ResourceRef) DeepCopy() *ResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(v1.RoleRef)
+		**out = **in
+	}
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(v1.Subject)
+		**out = **in
+	}
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(ManagedRole)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
+	if in == nil {
+		return nil
+	}
+	out := new(Role)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedCRD) DeepCopyInto(out *SkippedCRD) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedCRD.
+func (in *SkippedCRD) DeepCopy() *SkippedCRD {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedCRD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnresolvedResourceRef) DeepCopyInto(out *UnresolvedResourceRef) {
+	*out = *in
+	out.ResourceRef = in.ResourceRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnresolvedResourceRef.
+func (in *UnresolvedResourceRef) DeepCopy() *UnresolvedResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(UnresolvedResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// authenticationConfigMap is published by the kube-apiserver for extension
+	// API servers, it holds the CA and the headers of the aggregator requests.
+	authenticationConfigMap = "extension-apiserver-authentication"
+	// authenticationNamespace is the namespace of authenticationConfigMap.
+	authenticationNamespace = "kube-system"
+)
+
+// errUnauthenticated is returned when a request does not carry a user.
+var errUnauthenticated = errors.New("request is not authenticated")
+
+// User is the user a request is made on behalf of.
+type User struct {
+	Name   string
+	Groups []string
+	Extra  map[string][]string
+}
+
+// Authenticator finds the user a request is made on behalf of.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*User, error)
+}
+
+// Authorizer decides whether a user may access a resource.
+type Authorizer interface {
+	Authorize(ctx context.Context, user *User, attrs authorizationv1.ResourceAttributes) (bool, error)
+}
+
+// RequestHeaderAuthenticator trusts the user headers set by the aggregator
+// of the kube-apiserver, once its client certificate is verified.
+type RequestHeaderAuthenticator struct {
+	clientCA            *x509.CertPool
+	allowedNames        []string
+	usernameHeaders     []string
+	groupHeaders        []string
+	extraHeaderPrefixes []string
+}
+
+var _ Authenticator = (*RequestHeaderAuthenticator)(nil)
+
+// NewRequestHeaderAuthenticator creates a RequestHeaderAuthenticator from the
+// extension-apiserver-authentication ConfigMap in kube-system.
+func NewRequestHeaderAuthenticator(ctx context.Context, client kubernetes.Interface) (*RequestHeaderAuthenticator, error) {
+	cm, err := client.CoreV1().ConfigMaps(authenticationNamespace).Get(ctx, authenticationConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the request header configuration: %w", err)
+	}
+
+	ca, ok := cm.Data["requestheader-client-ca-file"]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s has no requestheader-client-ca-file", authenticationNamespace, authenticationConfigMap)
+	}
+	a := &RequestHeaderAuthenticator{clientCA: x509.NewCertPool()}
+	if !a.clientCA.AppendCertsFromPEM([]byte(ca)) {
+		return nil, errors.New("requestheader-client-ca-file holds no certificates")
+	}
+
+	for key, into := range map[string]*[]string{
+		"requestheader-allowed-names":        &a.allowedNames,
+		"requestheader-username-headers":     &a.usernameHeaders,
+		"requestheader-group-headers":        &a.groupHeaders,
+		"requestheader-extra-headers-prefix": &a.extraHeaderPrefixes,
+	} {
+		if v, ok := cm.Data[key]; ok && v != "" {
+			if err := json.Unmarshal([]byte(v), into); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+			}
+		}
+	}
+	return a, nil
+}
+
+// Authenticate verifies the client certificate of the request against the
+// request header CA and reads the user from the request headers.
+func (a *RequestHeaderAuthenticator) Authenticate(r *http.Request) (*User, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil, errUnauthenticated
+	}
+
+	certs := r.TLS.PeerCertificates
+	opts := x509.VerifyOptions{
+		Roots:         a.clientCA,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return nil, fmt.Errorf("failed to verify the client certificate: %w", err)
+	}
+	if len(a.allowedNames) > 0 && !contains(a.allowedNames, certs[0].Subject.CommonName) {
+		return nil, fmt.Errorf("client certificate %q is not allowed", certs[0].Subject.CommonName)
+	}
+
+	user := &User{}
+	for _, h := range a.usernameHeaders {
+		if user.Name = r.Header.Get(h); user.Name != "" {
+			break
+		}
+	}
+	if user.Name == "" {
+		return nil, errUnauthenticated
+	}
+	for _, h := range a.groupHeaders {
+		user.Groups = append(user.Groups, r.Header.Values(h)...)
+	}
+	for h, values := range r.Header {
+		for _, prefix := range a.extraHeaderPrefixes {
+			if !strings.HasPrefix(strings.ToLower(h), strings.ToLower(prefix)) {
+				continue
+			}
+			key, err := url.PathUnescape(strings.ToLower(h[len(prefix):]))
+			if err != nil {
+				continue
+			}
+			if user.Extra == nil {
+				user.Extra = make(map[string][]string)
+			}
+			user.Extra[key] = append(user.Extra[key], values...)
+		}
+	}
+	return user, nil
+}
+
+// SubjectAccessReviewAuthorizer asks the kube-apiserver whether a user may
+// access a resource.
+type SubjectAccessReviewAuthorizer struct {
+	client kubernetes.Interface
+}
+
+var _ Authorizer = (*SubjectAccessReviewAuthorizer)(nil)
+
+// NewSubjectAccessReviewAuthorizer creates a SubjectAccessReviewAuthorizer.
+func NewSubjectAccessReviewAuthorizer(client kubernetes.Interface) *SubjectAccessReviewAuthorizer {
+	return &SubjectAccessReviewAuthorizer{client: client}
+}
+
+// Authorize creates a SubjectAccessReview for the user and the resource.
+func (a *SubjectAccessReviewAuthorizer) Authorize(ctx context.Context, user *User, attrs authorizationv1.ResourceAttributes) (bool, error) {
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attrs,
+			User:               user.Name,
+			Groups:             user.Groups,
+		},
+	}
+	if len(user.Extra) > 0 {
+		sar.Spec.Extra = make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for k, v := range user.Extra {
+			sar.Spec.Extra[k] = v
+		}
+	}
+
+	sar, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
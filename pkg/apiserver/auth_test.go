@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// newCert creates a certificate signed by parent, or a self-signed CA if
+// parent is nil.
+func newCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("Failed to generate a key:", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal("Failed to create a certificate:", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal("Failed to parse the certificate:", err)
+	}
+	return cert, key
+}
+
+func TestRequestHeaderAuthenticator(t *testing.T) {
+	ca, caKey := newCert(t, "front-proxy-ca", nil, nil)
+	proxy, _ := newCert(t, "front-proxy-client", ca, caKey)
+	other, _ := newCert(t, "other-client", ca, caKey)
+	otherCA, otherCAKey := newCert(t, "other-ca", nil, nil)
+	stranger, _ := newCert(t, "front-proxy-client", otherCA, otherCAKey)
+
+	client := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      authenticationConfigMap,
+			Namespace: authenticationNamespace,
+		},
+		Data: map[string]string{
+			"requestheader-client-ca-file":       string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})),
+			"requestheader-allowed-names":        `["front-proxy-client"]`,
+			"requestheader-username-headers":     `["X-Remote-User"]`,
+			"requestheader-group-headers":        `["X-Remote-Group"]`,
+			"requestheader-extra-headers-prefix": `["X-Remote-Extra-"]`,
+		},
+	})
+	authn, err := NewRequestHeaderAuthenticator(context.Background(), client)
+	if err != nil {
+		t.Fatal("NewRequestHeaderAuthenticator() =", err)
+	}
+
+	tests := []struct {
+		name    string
+		cert    *x509.Certificate
+		user    string
+		want    *User
+		wantErr bool
+	}{{
+		name: "aggregator",
+		cert: proxy,
+		user: "alice",
+		want: &User{
+			Name:   "alice",
+			Groups: []string{"system:authenticated", "devs"},
+			Extra:  map[string][]string{"scopes.example.com/team": {"ducks"}},
+		},
+	}, {
+		name:    "no certificate",
+		user:    "alice",
+		wantErr: true,
+	}, {
+		name:    "name not allowed",
+		cert:    other,
+		user:    "alice",
+		wantErr: true,
+	}, {
+		name:    "other CA",
+		cert:    stranger,
+		user:    "alice",
+		wantErr: true,
+	}, {
+		name:    "no user",
+		cert:    proxy,
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/apis/duck.knative.dev/v1/addressables", nil)
+			if tc.cert != nil {
+				r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{tc.cert}}
+			}
+			if tc.user != "" {
+				r.Header.Set("X-Remote-User", tc.user)
+			}
+			r.Header.Add("X-Remote-Group", "system:authenticated")
+			r.Header.Add("X-Remote-Group", "devs")
+			r.Header.Set("X-Remote-Extra-Scopes.example.com%2fteam", "ducks")
+
+			got, err := authn.Authenticate(r)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Authenticate() = %v, wanted error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("user (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestNewRequestHeaderAuthenticator_NotFound(t *testing.T) {
+	if _, err := NewRequestHeaderAuthenticator(context.Background(), kubefake.NewSimpleClientset()); err == nil {
+		t.Error("NewRequestHeaderAuthenticator() = nil, wanted an error")
+	}
+}
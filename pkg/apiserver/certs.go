@@ -0,0 +1,234 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/webhook/certificates/resources"
+)
+
+const (
+	// certLifetime is how long a generated serving certificate is valid.
+	certLifetime = 365 * 24 * time.Hour
+	// certRenewal is how long before it expires a serving certificate is
+	// generated again.
+	certRenewal = 30 * 24 * time.Hour
+	// certCheckInterval is how often the secret is checked for a certificate
+	// to renew, or renewed by another replica.
+	certCheckInterval = time.Hour
+)
+
+var apiServiceResource = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+// EnsureCertificate returns the serving certificate of the service and the CA
+// that signed it, kept in the secret so that the replicas serve the same
+// certificate and it survives restarts. A certificate is generated when the
+// secret does not hold one, or when it is about to expire.
+func EnsureCertificate(ctx context.Context, client kubernetes.Interface, namespace, name, service string) (tls.Certificate, []byte, error) {
+	secrets := client.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	exists := err == nil
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+	case err != nil:
+		return tls.Certificate{}, nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	default:
+		if pair, ca, err := certificateOf(secret, time.Now().Add(certRenewal)); err == nil {
+			return pair, ca, nil
+		}
+	}
+
+	key, cert, ca, err := resources.CreateCerts(ctx, service, namespace, time.Now().Add(certLifetime))
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to create a certificate: %w", err)
+	}
+	secret = secret.DeepCopy()
+	secret.Data = map[string][]byte{
+		resources.ServerKey:  key,
+		resources.ServerCert: cert,
+		resources.CACert:     ca,
+	}
+	if exists {
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	} else {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	}
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		// Another replica stored a certificate first, serve that one.
+		if secret, err = secrets.Get(ctx, name, metav1.GetOptions{}); err != nil {
+			return tls.Certificate{}, nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+		}
+		return certificateOf(secret, time.Now())
+	}
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to store the certificate in secret %s/%s: %w", namespace, name, err)
+	}
+	pair, err := tls.X509KeyPair(cert, key)
+	return pair, ca, err
+}
+
+// certificateOf returns the certificate held by the secret and its CA, if it
+// is still valid at the given time.
+func certificateOf(secret *corev1.Secret, at time.Time) (tls.Certificate, []byte, error) {
+	pair, err := tls.X509KeyPair(secret.Data[resources.ServerCert], secret.Data[resources.ServerKey])
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	block, _ := pem.Decode(secret.Data[resources.CACert])
+	if block == nil {
+		return tls.Certificate{}, nil, fmt.Errorf("secret %s/%s does not hold a CA", secret.Namespace, secret.Name)
+	}
+	for _, der := range [][]byte{pair.Certificate[0], block.Bytes} {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return tls.Certificate{}, nil, err
+		}
+		if at.After(cert.NotAfter) {
+			return tls.Certificate{}, nil, fmt.Errorf("certificate of secret %s/%s expires at %v", secret.Namespace, secret.Name, cert.NotAfter)
+		}
+	}
+	return pair, secret.Data[resources.CACert], nil
+}
+
+// InjectCABundle sets the CA the aggregator verifies the serving certificate
+// with on the APIServices, and stops them from skipping the verification.
+func InjectCABundle(ctx context.Context, client dynamic.Interface, apiServices []string, caBundle []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(caBundle)
+	for _, name := range apiServices {
+		if name == "" {
+			continue
+		}
+		apiService, err := client.Resource(apiServiceResource).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get APIService %s: %w", name, err)
+		}
+		current, _, _ := unstructured.NestedString(apiService.Object, "spec", "caBundle")
+		insecure, _, _ := unstructured.NestedBool(apiService.Object, "spec", "insecureSkipTLSVerify")
+		if current == encoded && !insecure {
+			continue
+		}
+		if err := unstructured.SetNestedField(apiService.Object, encoded, "spec", "caBundle"); err != nil {
+			return err
+		}
+		unstructured.RemoveNestedField(apiService.Object, "spec", "insecureSkipTLSVerify")
+		if _, err := client.Resource(apiServiceResource).Update(ctx, apiService, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update APIService %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// CertificateKeeper serves the certificate kept in a secret, renews it before
+// it expires and sets its CA on the APIServices when it changes.
+type CertificateKeeper struct {
+	client      kubernetes.Interface
+	dynamic     dynamic.Interface
+	namespace   string
+	name        string
+	service     string
+	apiServices []string
+
+	// cert holds the *tls.Certificate being served.
+	cert atomic.Value
+	// ca is the CA of the served certificate, only used by Check.
+	ca []byte
+}
+
+// NewCertificateKeeper returns a keeper of the serving certificate of the
+// service in the secret namespace/name. Check loads it.
+func NewCertificateKeeper(client kubernetes.Interface, dynamic dynamic.Interface, namespace, name, service string, apiServices []string) *CertificateKeeper {
+	return &CertificateKeeper{
+		client:      client,
+		dynamic:     dynamic,
+		namespace:   namespace,
+		name:        name,
+		service:     service,
+		apiServices: apiServices,
+	}
+}
+
+// GetCertificate serves the certificate, for tls.Config.
+func (k *CertificateKeeper) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, _ := k.cert.Load().(*tls.Certificate)
+	if cert == nil {
+		return nil, fmt.Errorf("no certificate loaded from secret %s/%s", k.namespace, k.name)
+	}
+	return cert, nil
+}
+
+// Check loads the certificate kept in the secret, renewing it if it is about
+// to expire. When its CA changes, the APIServices are set to trust both the
+// new and the previous CA, so that the replicas still serving the previous
+// certificate are verified until they load the new one.
+func (k *CertificateKeeper) Check(ctx context.Context) error {
+	pair, ca, err := EnsureCertificate(ctx, k.client, k.namespace, k.name, k.service)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(ca, k.ca) {
+		bundle := append(append([]byte{}, ca...), k.ca...)
+		if err := InjectCABundle(ctx, k.dynamic, k.apiServices, bundle); err != nil {
+			return err
+		}
+		k.ca = ca
+	}
+	k.cert.Store(&pair)
+	return nil
+}
+
+// Run checks the certificate every certCheckInterval until the context is
+// done. A failed check is logged and retried with the next one, the current
+// certificate is served until then.
+func (k *CertificateKeeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(certCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.Check(ctx); err != nil {
+				logging.FromContext(ctx).Warnw("Failed to check the serving certificate", zap.Error(err))
+			}
+		}
+	}
+}
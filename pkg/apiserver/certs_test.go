@@ -0,0 +1,187 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/webhook/certificates/resources"
+)
+
+func TestEnsureCertificate(t *testing.T) {
+	ctx := context.Background()
+	client := kubefake.NewSimpleClientset()
+
+	pair, ca, err := EnsureCertificate(ctx, client, "knative-discovery", "apiserver-certs", "apiserver")
+	if err != nil {
+		t.Fatal("EnsureCertificate() =", err)
+	}
+	secret, err := client.CoreV1().Secrets("knative-discovery").Get(ctx, "apiserver-certs", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("the certificate is not kept in the secret:", err)
+	}
+	if !bytes.Equal(ca, secret.Data[resources.CACert]) {
+		t.Error("the CA is not the one kept in the secret")
+	}
+
+	// The certificate kept in the secret is served again.
+	again, _, err := EnsureCertificate(ctx, client, "knative-discovery", "apiserver-certs", "apiserver")
+	if err != nil {
+		t.Fatal("EnsureCertificate() =", err)
+	}
+	if !bytes.Equal(pair.Certificate[0], again.Certificate[0]) {
+		t.Error("a new certificate was generated although the secret holds a valid one")
+	}
+
+	// A secret not holding a valid certificate is updated.
+	secret.Data[resources.ServerCert] = []byte("garbage")
+	if _, err := client.CoreV1().Secrets("knative-discovery").Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal("failed to update the secret:", err)
+	}
+	renewed, _, err := EnsureCertificate(ctx, client, "knative-discovery", "apiserver-certs", "apiserver")
+	if err != nil {
+		t.Fatal("EnsureCertificate() =", err)
+	}
+	if bytes.Equal(pair.Certificate[0], renewed.Certificate[0]) {
+		t.Error("the certificate was not generated again")
+	}
+}
+
+func TestInjectCABundle(t *testing.T) {
+	apiService := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiregistration.k8s.io/v1",
+		"kind":       "APIService",
+		"metadata": map[string]interface{}{
+			"name": "v1.duck.knative.dev",
+		},
+		"spec": map[string]interface{}{
+			"group":                 "duck.knative.dev",
+			"version":               "v1",
+			"insecureSkipTLSVerify": true,
+		},
+	}}
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), apiService)
+
+	if err := InjectCABundle(context.Background(), client, []string{"v1.duck.knative.dev"}, []byte("ca")); err != nil {
+		t.Fatal("InjectCABundle() =", err)
+	}
+	got, err := client.Resource(apiServiceResource).Get(context.Background(), "v1.duck.knative.dev", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("failed to get the APIService:", err)
+	}
+	if caBundle, _, _ := unstructured.NestedString(got.Object, "spec", "caBundle"); caBundle != base64.StdEncoding.EncodeToString([]byte("ca")) {
+		t.Errorf("caBundle = %q, want the encoded CA", caBundle)
+	}
+	if _, found, _ := unstructured.NestedBool(got.Object, "spec", "insecureSkipTLSVerify"); found {
+		t.Error("insecureSkipTLSVerify is still set")
+	}
+
+	if err := InjectCABundle(context.Background(), client, []string{"v1.unknown.knative.dev"}, []byte("ca")); err == nil {
+		t.Error("expected an error for an unknown APIService")
+	}
+}
+
+func TestCertificateKeeper(t *testing.T) {
+	ctx := context.Background()
+	client := kubefake.NewSimpleClientset()
+	apiService := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiregistration.k8s.io/v1",
+		"kind":       "APIService",
+		"metadata": map[string]interface{}{
+			"name": "v1.duck.knative.dev",
+		},
+		"spec": map[string]interface{}{
+			"group":   "duck.knative.dev",
+			"version": "v1",
+		},
+	}}
+	dynamic := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), apiService)
+	caBundle := func() []byte {
+		got, err := dynamic.Resource(apiServiceResource).Get(ctx, "v1.duck.knative.dev", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal("failed to get the APIService:", err)
+		}
+		encoded, _, _ := unstructured.NestedString(got.Object, "spec", "caBundle")
+		bundle, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			t.Fatal("failed to decode the caBundle:", err)
+		}
+		return bundle
+	}
+
+	k := NewCertificateKeeper(client, dynamic, "knative-discovery", "apiserver-certs", "apiserver", []string{"v1.duck.knative.dev"})
+	if _, err := k.GetCertificate(nil); err == nil {
+		t.Error("expected GetCertificate() to fail before the certificate is loaded")
+	}
+	if err := k.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	served, err := k.GetCertificate(nil)
+	if err != nil {
+		t.Fatal("GetCertificate() =", err)
+	}
+	secret, err := client.CoreV1().Secrets("knative-discovery").Get(ctx, "apiserver-certs", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("the certificate is not kept in the secret:", err)
+	}
+	ca := secret.Data[resources.CACert]
+	if got := caBundle(); !bytes.Equal(got, ca) {
+		t.Errorf("caBundle = %q, want the CA of the secret", got)
+	}
+
+	// A certificate about to expire is renewed and served, and the
+	// APIServices trust both CAs.
+	key, cert, expiring, err := resources.CreateCerts(ctx, "apiserver", "knative-discovery", time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatal("failed to create a certificate:", err)
+	}
+	secret.Data = map[string][]byte{
+		resources.ServerKey:  key,
+		resources.ServerCert: cert,
+		resources.CACert:     expiring,
+	}
+	if _, err := client.CoreV1().Secrets("knative-discovery").Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal("failed to update the secret:", err)
+	}
+	if err := k.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	renewed, err := k.GetCertificate(nil)
+	if err != nil {
+		t.Fatal("GetCertificate() =", err)
+	}
+	if bytes.Equal(renewed.Certificate[0], served.Certificate[0]) || bytes.Equal(renewed.Certificate[0], cert) {
+		t.Error("the expiring certificate was not renewed")
+	}
+	secret, err = client.CoreV1().Secrets("knative-discovery").Get(ctx, "apiserver-certs", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("failed to get the secret:", err)
+	}
+	newCA := secret.Data[resources.CACert]
+	if got, want := caBundle(), append(append([]byte{}, newCA...), ca...); !bytes.Equal(got, want) {
+		t.Errorf("caBundle = %q, want the new and the previous CA", got)
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	"knative.dev/discovery/pkg/table"
+)
+
+// backend is a resource of a duck, backing a virtual resource.
+type backend struct {
+	meta v1alpha1.ResourceMeta
+	gvr  schema.GroupVersionResource
+}
+
+// resourceInterface returns the client of the backend in the namespace.
+func (b backend) resourceInterface(client dynamic.Interface, namespace string) dynamic.ResourceInterface {
+	if b.meta.Scope == v1alpha1.ClusterScoped {
+		return client.Resource(b.gvr)
+	}
+	return client.Resource(b.gvr).Namespace(namespace)
+}
+
+// resourceVersions maps the backends of a virtual resource to their resource
+// versions. Encoded, it is the resource version of the virtual resource.
+type resourceVersions map[string]string
+
+// parseResourceVersions decodes the resource version of a virtual resource.
+// The empty and "0" resource versions apply to every backend.
+func parseResourceVersions(rv string) (resourceVersions, error) {
+	rvs := make(resourceVersions)
+	if rv == "" || rv == "0" {
+		rvs[""] = rv
+		return rvs, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(rv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource version %q", rv)
+	}
+	if err := json.Unmarshal(data, &rvs); err != nil {
+		return nil, fmt.Errorf("invalid resource version %q", rv)
+	}
+	return rvs, nil
+}
+
+// get returns the resource version of the backend.
+func (rvs resourceVersions) get(gvr schema.GroupVersionResource) string {
+	if rv, ok := rvs[""]; ok {
+		return rv
+	}
+	return rvs[gvr.String()]
+}
+
+// set records the resource version of the backend.
+func (rvs resourceVersions) set(gvr schema.GroupVersionResource, rv string) {
+	delete(rvs, "")
+	rvs[gvr.String()] = rv
+}
+
+// String encodes the resource versions.
+func (rvs resourceVersions) String() string {
+	data, _ := json.Marshal(rvs)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// serveResource lists or watches the instances of the ducks of the version of
+// the duck type.
+func (s *Server) serveResource(w http.ResponseWriter, r *http.Request, group, version, namespace, resource string) {
+	dt, err := s.duckType(group, version, resource)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	user, err := s.authn.Authenticate(r)
+	if err != nil {
+		writeError(w, apierrors.NewUnauthorized(err.Error()))
+		return
+	}
+
+	opts := metav1.ListOptions{}
+	query := r.URL.Query()
+	if err := metav1.Convert_url_Values_To_v1_ListOptions(&query, &opts, nil); err != nil {
+		writeError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	verb := "list"
+	if opts.Watch {
+		verb = "watch"
+	}
+
+	backends, denied, err := s.authorizedBackends(r.Context(), user, verb, namespace, dt.Status.Ducks[version])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// Ducks skipped for their scope leave an empty result, not a denial.
+	if len(backends) == 0 && denied > 0 {
+		writeError(w, apierrors.NewForbidden(schema.GroupResource{Group: group, Resource: resource}, "",
+			fmt.Errorf("user %q cannot %s the ducks of %s", user.Name, verb, dt.Name)))
+		return
+	}
+
+	var convertor *table.Convertor
+	if wantsTable(r) {
+		columns, err := table.Columns(dt, version)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if convertor, err = table.NewConvertor(columns); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	if opts.Watch {
+		s.watch(w, r, backends, namespace, opts, convertor)
+		return
+	}
+	s.list(w, r, dt, version, backends, namespace, opts, convertor)
+}
+
+// authorizedBackends returns the backends of the ducks the user may access,
+// and how many the user was denied. A kind is accessed once, using its
+// preferred version, and cluster scoped ducks are skipped in a namespace.
+func (s *Server) authorizedBackends(ctx context.Context, user *User, verb, namespace string, ducks []v1alpha1.ResourceMeta) ([]backend, int, error) {
+	backends := make([]backend, 0, len(ducks))
+	denied := 0
+	for _, meta := range v1alpha1.PreferredVersions(ducks) {
+		gv, err := schema.ParseGroupVersion(meta.APIVersion)
+		if err != nil || meta.Resource == "" {
+			continue
+		}
+		if meta.Scope == v1alpha1.ClusterScoped && namespace != "" {
+			continue
+		}
+
+		b := backend{meta: meta, gvr: gv.WithResource(meta.Resource)}
+		attrs := authorizationv1.ResourceAttributes{
+			Verb:     verb,
+			Group:    b.gvr.Group,
+			Version:  b.gvr.Version,
+			Resource: b.gvr.Resource,
+		}
+		if meta.Scope != v1alpha1.ClusterScoped {
+			attrs.Namespace = namespace
+		}
+		allowed, err := s.authz.Authorize(ctx, user, attrs)
+		if err != nil {
+			return nil, 0, apierrors.NewInternalError(fmt.Errorf("failed to authorize %s: %w", b.gvr.GroupResource(), err))
+		}
+		if allowed {
+			backends = append(backends, b)
+		} else {
+			denied++
+		}
+	}
+	return backends, denied, nil
+}
+
+// list fans out the list to the backends and merges the results.
+func (s *Server) list(w http.ResponseWriter, r *http.Request, dt *v1alpha1.ClusterDuckType, version string, backends []backend, namespace string, opts metav1.ListOptions, convertor *table.Convertor) {
+	rvs, err := parseResourceVersions(opts.ResourceVersion)
+	if err != nil {
+		writeError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	items := make([]unstructured.Unstructured, 0)
+	for _, b := range backends {
+		list, err := b.resourceInterface(s.client, namespace).List(r.Context(), metav1.ListOptions{
+			LabelSelector:   opts.LabelSelector,
+			FieldSelector:   opts.FieldSelector,
+			ResourceVersion: rvs.get(b.gvr),
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		rvs.set(b.gvr, list.GetResourceVersion())
+
+		sort.Slice(list.Items, func(i, j int) bool {
+			if list.Items[i].GetNamespace() != list.Items[j].GetNamespace() {
+				return list.Items[i].GetNamespace() < list.Items[j].GetNamespace()
+			}
+			return list.Items[i].GetName() < list.Items[j].GetName()
+		})
+		items = append(items, list.Items...)
+	}
+
+	if convertor != nil {
+		instances := make([]*unstructured.Unstructured, 0, len(items))
+		for i := range items {
+			instances = append(instances, &items[i])
+		}
+		t := convertor.Table(instances)
+		t.ResourceVersion = rvs.String()
+		writeJSON(w, http.StatusOK, t)
+		return
+	}
+
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{
+			"apiVersion": dt.Spec.Group + "/" + version,
+			"kind":       dt.Spec.Names.Name + "List",
+		},
+		Items: items,
+	}
+	list.SetResourceVersion(rvs.String())
+	writeJSON(w, http.StatusOK, list)
+}
+
+// wantsTable reports whether the client accepts a metav1.Table.
+func wantsTable(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		params := strings.Split(strings.ReplaceAll(accept, " ", ""), ";")
+		if contains(params, "as=Table") && contains(params, "g=meta.k8s.io") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	listers "knative.dev/discovery/pkg/client/listers/discovery/v1alpha1"
+)
+
+// Server serves each version of each ClusterDuckType as a virtual resource
+// of the API group of the duck type. The resource is namespaced and can be
+// listed and watched, calls are fanned out to the ducks in the status of the
+// ClusterDuckType.
+type Server struct {
+	lister listers.ClusterDuckTypeLister
+	client dynamic.Interface
+	authn  Authenticator
+	authz  Authorizer
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// NewServer creates a Server. The ducks are accessed with client, on behalf of
+// the users found by authn once authz allows them access.
+func NewServer(lister listers.ClusterDuckTypeLister, client dynamic.Interface, authn Authenticator, authz Authorizer) *Server {
+	return &Server{
+		lister: lister,
+		client: client,
+		authn:  authn,
+		authz:  authz,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/healthz", "/livez", "/readyz":
+		w.Write([]byte("ok"))
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, apierrors.NewMethodNotSupported(schema.GroupResource{}, r.Method))
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "apis" {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, r.URL.Path))
+		return
+	}
+
+	// Discovery is public, like it is for the kube-apiserver.
+	switch parts = parts[1:]; len(parts) {
+	case 0:
+		s.serveGroupList(w)
+	case 1:
+		s.serveGroup(w, parts[0])
+	case 2:
+		s.serveResourceList(w, parts[0], parts[1])
+	case 3:
+		s.serveResource(w, r, parts[0], parts[1], "", parts[2])
+	case 5:
+		if parts[2] == "namespaces" {
+			s.serveResource(w, r, parts[0], parts[1], parts[3], parts[4])
+			return
+		}
+		fallthrough
+	default:
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, r.URL.Path))
+	}
+}
+
+// groups returns the versions of the groups of the ClusterDuckTypes, ordered
+// by priority.
+func (s *Server) groups() (map[string][]string, error) {
+	dts, err := s.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]string)
+	for _, dt := range dts {
+		for _, dv := range dt.Spec.Versions {
+			if !contains(groups[dt.Spec.Group], dv.Name) {
+				groups[dt.Spec.Group] = append(groups[dt.Spec.Group], dv.Name)
+			}
+		}
+	}
+	for _, versions := range groups {
+		sort.Slice(versions, func(i, j int) bool {
+			return version.CompareKubeAwareVersionStrings(versions[i], versions[j]) > 0
+		})
+	}
+	return groups, nil
+}
+
+func apiGroup(group string, versions []string) metav1.APIGroup {
+	g := metav1.APIGroup{Name: group}
+	for _, v := range versions {
+		g.Versions = append(g.Versions, metav1.GroupVersionForDiscovery{
+			GroupVersion: group + "/" + v,
+			Version:      v,
+		})
+	}
+	if len(g.Versions) > 0 {
+		g.PreferredVersion = g.Versions[0]
+	}
+	return g
+}
+
+func (s *Server) serveGroupList(w http.ResponseWriter) {
+	groups, err := s.groups()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	list := &metav1.APIGroupList{
+		TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"},
+		Groups:   make([]metav1.APIGroup, 0, len(groups)),
+	}
+	for group, versions := range groups {
+		list.Groups = append(list.Groups, apiGroup(group, versions))
+	}
+	sort.Slice(list.Groups, func(i, j int) bool {
+		return list.Groups[i].Name < list.Groups[j].Name
+	})
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) serveGroup(w http.ResponseWriter, group string) {
+	groups, err := s.groups()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versions, ok := groups[group]
+	if !ok {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, group))
+		return
+	}
+
+	g := apiGroup(group, versions)
+	g.TypeMeta = metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"}
+	writeJSON(w, http.StatusOK, &g)
+}
+
+func (s *Server) serveResourceList(w http.ResponseWriter, group, version string) {
+	dts, err := s.lister.List(labels.Everything())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: group + "/" + version,
+		APIResources: make([]metav1.APIResource, 0),
+	}
+	for _, dt := range dts {
+		if dt.Spec.Group != group || !hasVersion(dt, version) {
+			continue
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:         dt.Spec.Names.Plural,
+			SingularName: dt.Spec.Names.Singular,
+			Namespaced:   true,
+			Kind:         dt.Spec.Names.Name,
+			Verbs:        metav1.Verbs{"list", "watch"},
+			Categories:   []string{"ducks"},
+		})
+	}
+	if len(list.APIResources) == 0 {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, list.GroupVersion))
+		return
+	}
+	sort.Slice(list.APIResources, func(i, j int) bool {
+		return list.APIResources[i].Name < list.APIResources[j].Name
+	})
+	writeJSON(w, http.StatusOK, list)
+}
+
+// duckType finds the ClusterDuckType serving the resource in the version.
+func (s *Server) duckType(group, version, resource string) (*v1alpha1.ClusterDuckType, error) {
+	dts, err := s.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, dt := range dts {
+		if dt.Spec.Group == group && dt.Spec.Names.Plural == resource && hasVersion(dt, version) {
+			return dt, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{Group: group, Resource: resource}, "")
+}
+
+func hasVersion(dt *v1alpha1.ClusterDuckType, version string) bool {
+	for _, dv := range dt.Spec.Versions {
+		if dv.Name == version {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON writes obj as the JSON body of the response.
+func writeJSON(w http.ResponseWriter, code int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(obj)
+}
+
+// writeError writes err as a metav1.Status.
+func writeError(w http.ResponseWriter, err error) {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		status = apierrors.NewInternalError(err)
+	}
+	s := status.Status()
+	s.TypeMeta = metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}
+	writeJSON(w, int(s.Code), &s)
+}
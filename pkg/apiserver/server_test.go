@@ -0,0 +1,391 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	listers "knative.dev/discovery/pkg/client/listers/discovery/v1alpha1"
+)
+
+var (
+	services = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"}
+	channels = schema.GroupVersionResource{Group: "messaging.knative.dev", Version: "v1", Resource: "channels"}
+)
+
+// fakeAuthn authenticates every request as alice, unless it has no user.
+type fakeAuthn struct{}
+
+func (fakeAuthn) Authenticate(r *http.Request) (*User, error) {
+	if r.Header.Get("X-Remote-User") == "" {
+		return nil, errUnauthenticated
+	}
+	return &User{Name: r.Header.Get("X-Remote-User")}, nil
+}
+
+// fakeAuthz allows access to the resources it lists.
+type fakeAuthz map[string]bool
+
+func (f fakeAuthz) Authorize(_ context.Context, _ *User, attrs authorizationv1.ResourceAttributes) (bool, error) {
+	return f[attrs.Resource], nil
+}
+
+func addressables() *v1alpha1.ClusterDuckType {
+	return &v1alpha1.ClusterDuckType{
+		ObjectMeta: metav1.ObjectMeta{Name: "addressables.duck.knative.dev"},
+		Spec: v1alpha1.ClusterDuckTypeSpec{
+			Group: "duck.knative.dev",
+			Names: v1alpha1.DuckTypeNames{
+				Name:     "Addressable",
+				Plural:   "addressables",
+				Singular: "addressable",
+			},
+			Versions: []v1alpha1.DuckVersion{{
+				Name: "v1",
+				AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{{
+					Name:     "URL",
+					Type:     "string",
+					JSONPath: ".status.address.url",
+				}},
+			}, {
+				Name: "v1alpha1",
+			}, {
+				Name: "v1beta1",
+			}},
+		},
+		Status: v1alpha1.ClusterDuckTypeStatus{
+			Ducks: map[string][]v1alpha1.ResourceMeta{
+				"v1": {{
+					APIVersion: "messaging.knative.dev/v1",
+					Kind:       "Channel",
+					Resource:   "channels",
+					Scope:      v1alpha1.NamespaceScoped,
+				}, {
+					APIVersion: "serving.knative.dev/v1",
+					Kind:       "Service",
+					Resource:   "services",
+					Scope:      v1alpha1.NamespaceScoped,
+				}},
+				"v1beta1": {{
+					APIVersion: "networking.internal.knative.dev/v1alpha1",
+					Kind:       "ClusterDomainClaim",
+					Resource:   "clusterdomainclaims",
+					Scope:      v1alpha1.ClusterScoped,
+				}},
+			},
+		},
+	}
+}
+
+func instance(apiVersion, kind, namespace, name, url string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	if url != "" {
+		_ = unstructured.SetNestedField(u.Object, url, "status", "address", "url")
+	}
+	return u
+}
+
+func newServer(t *testing.T, authz fakeAuthz) (*Server, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(addressables()); err != nil {
+		t.Fatal("Failed to add the ClusterDuckType:", err)
+	}
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			services: "ServiceList",
+			channels: "ChannelList",
+		},
+		instance("serving.knative.dev/v1", "Service", "default", "hello", "http://hello.default.example.com"),
+		instance("messaging.knative.dev/v1", "Channel", "default", "events", ""),
+		instance("messaging.knative.dev/v1", "Channel", "other", "news", "http://news.other.svc"),
+	)
+	return NewServer(listers.NewClusterDuckTypeLister(indexer), client, fakeAuthn{}, authz), client
+}
+
+func get(s *Server, path, accept string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	r.Header.Set("X-Remote-User", "alice")
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func TestDiscovery(t *testing.T) {
+	s, _ := newServer(t, nil)
+
+	w := get(s, "/apis/duck.knative.dev/v1", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Code = %d, wanted %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	got := &metav1.APIResourceList{}
+	if err := json.Unmarshal(w.Body.Bytes(), got); err != nil {
+		t.Fatal("Failed to decode:", err)
+	}
+	want := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: "duck.knative.dev/v1",
+		APIResources: []metav1.APIResource{{
+			Name:         "addressables",
+			SingularName: "addressable",
+			Namespaced:   true,
+			Kind:         "Addressable",
+			Verbs:        metav1.Verbs{"list", "watch"},
+			Categories:   []string{"ducks"},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("APIResourceList (-want, +got):", diff)
+	}
+
+	w = get(s, "/apis/duck.knative.dev", "")
+	group := &metav1.APIGroup{}
+	if err := json.Unmarshal(w.Body.Bytes(), group); err != nil {
+		t.Fatal("Failed to decode:", err)
+	}
+	if got, want := group.PreferredVersion.Version, "v1"; got != want {
+		t.Errorf("PreferredVersion = %s, wanted %s", got, want)
+	}
+
+	if w := get(s, "/apis/duck.knative.dev/v2", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Code = %d, wanted %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		authz fakeAuthz
+		want  []string
+		code  int
+	}{{
+		name:  "all namespaces",
+		path:  "/apis/duck.knative.dev/v1/addressables",
+		authz: fakeAuthz{"services": true, "channels": true},
+		want:  []string{"default/events", "other/news", "default/hello"},
+		code:  http.StatusOK,
+	}, {
+		name:  "namespace",
+		path:  "/apis/duck.knative.dev/v1/namespaces/other/addressables",
+		authz: fakeAuthz{"services": true, "channels": true},
+		want:  []string{"other/news"},
+		code:  http.StatusOK,
+	}, {
+		name:  "partially authorized",
+		path:  "/apis/duck.knative.dev/v1/addressables",
+		authz: fakeAuthz{"services": true},
+		want:  []string{"default/hello"},
+		code:  http.StatusOK,
+	}, {
+		name: "not authorized",
+		path: "/apis/duck.knative.dev/v1/addressables",
+		code: http.StatusForbidden,
+	}, {
+		name: "no ducks in version",
+		path: "/apis/duck.knative.dev/v1alpha1/addressables",
+		want: []string{},
+		code: http.StatusOK,
+	}, {
+		name: "only cluster scoped ducks in namespace",
+		path: "/apis/duck.knative.dev/v1beta1/namespaces/default/addressables",
+		want: []string{},
+		code: http.StatusOK,
+	}, {
+		name: "cluster scoped ducks not authorized",
+		path: "/apis/duck.knative.dev/v1beta1/addressables",
+		code: http.StatusForbidden,
+	}, {
+		name: "unknown resource",
+		path: "/apis/duck.knative.dev/v1/podspecables",
+		code: http.StatusNotFound,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _ := newServer(t, tc.authz)
+			w := get(s, tc.path, "")
+			if w.Code != tc.code {
+				t.Fatalf("Code = %d, wanted %d: %s", w.Code, tc.code, w.Body)
+			}
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			list := &unstructured.UnstructuredList{}
+			if err := list.UnmarshalJSON(w.Body.Bytes()); err != nil {
+				t.Fatal("Failed to decode:", err)
+			}
+			if got, want := list.GetKind(), "AddressableList"; got != want {
+				t.Errorf("Kind = %s, wanted %s", got, want)
+			}
+			got := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				got = append(got, item.GetNamespace()+"/"+item.GetName())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("items (-want, +got):", diff)
+			}
+			if _, err := parseResourceVersions(list.GetResourceVersion()); err != nil {
+				t.Error("parseResourceVersions() =", err)
+			}
+		})
+	}
+}
+
+func TestList_Table(t *testing.T) {
+	s, _ := newServer(t, fakeAuthz{"services": true, "channels": true})
+
+	w := get(s, "/apis/duck.knative.dev/v1/namespaces/default/addressables", "application/json;as=Table;v=v1;g=meta.k8s.io,application/json")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Code = %d, wanted %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	got := &metav1.Table{}
+	if err := json.Unmarshal(w.Body.Bytes(), got); err != nil {
+		t.Fatal("Failed to decode:", err)
+	}
+	cells := make([][]interface{}, 0, len(got.Rows))
+	for _, row := range got.Rows {
+		cells = append(cells, row.Cells)
+	}
+	want := [][]interface{}{
+		{"events", "Channel.messaging.knative.dev", nil},
+		{"hello", "Service.serving.knative.dev", "http://hello.default.example.com"},
+	}
+	if diff := cmp.Diff(want, cells); diff != "" {
+		t.Error("cells (-want, +got):", diff)
+	}
+}
+
+func TestList_Unauthenticated(t *testing.T) {
+	s, _ := newServer(t, fakeAuthz{"services": true})
+
+	r := httptest.NewRequest(http.MethodGet, "/apis/duck.knative.dev/v1/addressables", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Code = %d, wanted %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	s, client := newServer(t, fakeAuthz{"services": true, "channels": true})
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/apis/duck.knative.dev/v1/namespaces/default/addressables?watch=true", nil)
+	r.Header.Set("X-Remote-User", "alice")
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatal("Failed to watch:", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Code = %d, wanted %d", resp.StatusCode, http.StatusOK)
+	}
+
+	// The watches of the backends are started before the response is sent.
+	if _, err := client.Resource(services).Namespace("default").Create(ctx,
+		instance("serving.knative.dev/v1", "Service", "default", "goodbye", ""), metav1.CreateOptions{}); err != nil {
+		t.Fatal("Failed to create a service:", err)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	if !scanner.Scan() {
+		t.Fatal("No watch event:", scanner.Err())
+	}
+	event := &metav1.WatchEvent{}
+	if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+		t.Fatal("Failed to decode:", err)
+	}
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(event.Object.Raw); err != nil {
+		t.Fatal("Failed to decode:", err)
+	}
+	if event.Type != "ADDED" || u.GetName() != "goodbye" {
+		t.Errorf("event = %s %s, wanted ADDED goodbye", event.Type, u.GetName())
+	}
+	rvs, err := parseResourceVersions(u.GetResourceVersion())
+	if err != nil {
+		t.Fatal("parseResourceVersions() =", err)
+	}
+	if _, ok := rvs[services.String()]; !ok {
+		t.Errorf("resource versions %v have no entry for %s", rvs, services)
+	}
+}
+
+func TestResourceVersions(t *testing.T) {
+	rvs, err := parseResourceVersions("")
+	if err != nil {
+		t.Fatal("parseResourceVersions() =", err)
+	}
+	if got := rvs.get(services); got != "" {
+		t.Errorf("get() = %q, wanted empty", got)
+	}
+
+	rvs.set(services, "42")
+	rvs.set(channels, "7")
+	parsed, err := parseResourceVersions(rvs.String())
+	if err != nil {
+		t.Fatal("parseResourceVersions() =", err)
+	}
+	if diff := cmp.Diff(rvs, parsed); diff != "" {
+		t.Error("resource versions (-want, +got):", diff)
+	}
+
+	if _, err := parseResourceVersions("12345"); err == nil || !strings.Contains(err.Error(), "invalid resource version") {
+		t.Error("parseResourceVersions() =", err)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeError(w, errors.New("boom"))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Code = %d, wanted %d", w.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"knative.dev/discovery/pkg/table"
+)
+
+// backendEvent is an event of the watch of a backend.
+type backendEvent struct {
+	backend backend
+	event   watch.Event
+}
+
+// watch fans out the watch to the backends and streams the merged events. The
+// watch ends when the watch of any backend ends. The resource versions of the
+// instances are replaced by the resource version of the virtual resource, so
+// clients can resume the watch.
+func (s *Server) watch(w http.ResponseWriter, r *http.Request, backends []backend, namespace string, opts metav1.ListOptions, convertor *table.Convertor) {
+	rvs, err := parseResourceVersions(opts.ResourceVersion)
+	if err != nil {
+		writeError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	if opts.TimeoutSeconds != nil {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*opts.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+
+	events := make(chan backendEvent)
+	for _, b := range backends {
+		wi, err := b.resourceInterface(s.client, namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:   opts.LabelSelector,
+			FieldSelector:   opts.FieldSelector,
+			ResourceVersion: rvs.get(b.gvr),
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		go func(b backend, wi watch.Interface) {
+			defer cancel()
+			defer wi.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-wi.ResultChan():
+					if !ok {
+						return
+					}
+					select {
+					case events <- backendEvent{backend: b, event: event}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(b, wi)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	enc := json.NewEncoder(w)
+	headers := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case be := <-events:
+			obj := be.event.Object
+			if u, ok := obj.(*unstructured.Unstructured); ok {
+				rvs.set(be.backend.gvr, u.GetResourceVersion())
+				u = u.DeepCopy()
+				u.SetResourceVersion(rvs.String())
+				obj = u
+				if convertor != nil {
+					t := convertor.Table([]*unstructured.Unstructured{u})
+					t.ResourceVersion = rvs.String()
+					if !headers {
+						t.ColumnDefinitions = nil
+					}
+					headers = false
+					obj = t
+				}
+			}
+			if err := enc.Encode(&metav1.WatchEvent{
+				Type:   string(be.event.Type),
+				Object: runtime.RawExtension{Object: obj},
+			}); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
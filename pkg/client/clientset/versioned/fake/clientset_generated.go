@@ -27,6 +27,8 @@ import (
 	clientset "knative.dev/discovery/pkg/client/clientset/versioned"
 	discoveryv1alpha1 "knative.dev/discovery/pkg/client/clientset/versioned/typed/discovery/v1alpha1"
 	fakediscoveryv1alpha1 "knative.dev/discovery/pkg/client/clientset/versioned/typed/discovery/v1alpha1/fake"
+	discoveryv1beta1 "knative.dev/discovery/pkg/client/clientset/versioned/typed/discovery/v1beta1"
+	fakediscoveryv1beta1 "knative.dev/discovery/pkg/client/clientset/versioned/typed/discovery/v1beta1/fake"
 )
 
 // NewSimpleClientset returns a clientset that will respond with the provided objects.
@@ -83,3 +85,8 @@ var (
 func (c *Clientset) DiscoveryV1alpha1() discoveryv1alpha1.DiscoveryV1alpha1Interface {
 	return &fakediscoveryv1alpha1.FakeDiscoveryV1alpha1{Fake: &c.Fake}
 }
+
+// DiscoveryV1beta1 retrieves the DiscoveryV1beta1Client
+func (c *Clientset) DiscoveryV1beta1() discoveryv1beta1.DiscoveryV1beta1Interface {
+	return &fakediscoveryv1beta1.FakeDiscoveryV1beta1{Fake: &c.Fake}
+}
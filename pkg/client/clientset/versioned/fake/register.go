@@ -25,6 +25,7 @@ import (
 	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	discoveryv1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	discoveryv1beta1 "knative.dev/discovery/pkg/apis/discovery/v1beta1"
 )
 
 var scheme = runtime.NewScheme()
@@ -32,6 +33,7 @@ var codecs = serializer.NewCodecFactory(scheme)
 
 var localSchemeBuilder = runtime.SchemeBuilder{
 	discoveryv1alpha1.AddToScheme,
+	discoveryv1beta1.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
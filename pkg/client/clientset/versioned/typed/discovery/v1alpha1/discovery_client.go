@@ -27,6 +27,8 @@ import (
 type DiscoveryV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterDuckTypesGetter
+	DuckTypesGetter
+	ManualsGetter
 }
 
 // DiscoveryV1alpha1Client is used to interact with features provided by the discovery.knative.dev group.
@@ -38,6 +40,14 @@ func (c *DiscoveryV1alpha1Client) ClusterDuckTypes() ClusterDuckTypeInterface {
 	return newClusterDuckTypes(c)
 }
 
+func (c *DiscoveryV1alpha1Client) DuckTypes(namespace string) DuckTypeInterface {
+	return newDuckTypes(c, namespace)
+}
+
+func (c *DiscoveryV1alpha1Client) Manuals() ManualInterface {
+	return newManuals(c)
+}
+
 // NewForConfig creates a new DiscoveryV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*DiscoveryV1alpha1Client, error) {
 	config := *c
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	scheme "knative.dev/discovery/pkg/client/clientset/versioned/scheme"
+)
+
+// DuckTypesGetter has a method to return a DuckTypeInterface.
+// A group's client should implement this interface.
+type DuckTypesGetter interface {
+	DuckTypes(namespace string) DuckTypeInterface
+}
+
+// DuckTypeInterface has methods to work with DuckType resources.
+type DuckTypeInterface interface {
+	Create(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.CreateOptions) (*v1alpha1.DuckType, error)
+	Update(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.UpdateOptions) (*v1alpha1.DuckType, error)
+	UpdateStatus(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.UpdateOptions) (*v1alpha1.DuckType, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.DuckType, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.DuckTypeList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DuckType, err error)
+	DuckTypeExpansion
+}
+
+// duckTypes implements DuckTypeInterface
+type duckTypes struct {
+	client rest.Interface
+	ns     string
+}
+
+// newDuckTypes returns a DuckTypes
+func newDuckTypes(c *DiscoveryV1alpha1Client, namespace string) *duckTypes {
+	return &duckTypes{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the duckType, and returns the corresponding duckType object, and an error if there is any.
+func (c *duckTypes) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DuckType, err error) {
+	result = &v1alpha1.DuckType{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of DuckTypes that match those selectors.
+func (c *duckTypes) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DuckTypeList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.DuckTypeList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested duckTypes.
+func (c *duckTypes) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a duckType and creates it.  Returns the server's representation of the duckType, and an error, if there is any.
+func (c *duckTypes) Create(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.CreateOptions) (result *v1alpha1.DuckType, err error) {
+	result = &v1alpha1.DuckType{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(duckType).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a duckType and updates it. Returns the server's representation of the duckType, and an error, if there is any.
+func (c *duckTypes) Update(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.UpdateOptions) (result *v1alpha1.DuckType, err error) {
+	result = &v1alpha1.DuckType{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		Name(duckType.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(duckType).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *duckTypes) UpdateStatus(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.UpdateOptions) (result *v1alpha1.DuckType, err error) {
+	result = &v1alpha1.DuckType{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		Name(duckType.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(duckType).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the duckType and deletes it. Returns an error if one occurs.
+func (c *duckTypes) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *duckTypes) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("ducktypes").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched duckType.
+func (c *duckTypes) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DuckType, err error) {
+	result = &v1alpha1.DuckType{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("ducktypes").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
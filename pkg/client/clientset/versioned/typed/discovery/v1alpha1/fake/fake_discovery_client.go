@@ -32,6 +32,14 @@ func (c *FakeDiscoveryV1alpha1) ClusterDuckTypes() v1alpha1.ClusterDuckTypeInter
 	return &FakeClusterDuckTypes{c}
 }
 
+func (c *FakeDiscoveryV1alpha1) DuckTypes(namespace string) v1alpha1.DuckTypeInterface {
+	return &FakeDuckTypes{c, namespace}
+}
+
+func (c *FakeDiscoveryV1alpha1) Manuals() v1alpha1.ManualInterface {
+	return &FakeManuals{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeDiscoveryV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// FakeDuckTypes implements DuckTypeInterface
+type FakeDuckTypes struct {
+	Fake *FakeDiscoveryV1alpha1
+	ns   string
+}
+
+var ducktypesResource = schema.GroupVersionResource{Group: "discovery.knative.dev", Version: "v1alpha1", Resource: "ducktypes"}
+
+var ducktypesKind = schema.GroupVersionKind{Group: "discovery.knative.dev", Version: "v1alpha1", Kind: "DuckType"}
+
+// Get takes name of the duckType, and returns the corresponding duckType object, and an error if there is any.
+func (c *FakeDuckTypes) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DuckType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(ducktypesResource, c.ns, name), &v1alpha1.DuckType{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DuckType), err
+}
+
+// List takes label and field selectors, and returns the list of DuckTypes that match those selectors.
+func (c *FakeDuckTypes) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DuckTypeList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(ducktypesResource, ducktypesKind, c.ns, opts), &v1alpha1.DuckTypeList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.DuckTypeList{ListMeta: obj.(*v1alpha1.DuckTypeList).ListMeta}
+	for _, item := range obj.(*v1alpha1.DuckTypeList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested duckTypes.
+func (c *FakeDuckTypes) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(ducktypesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a duckType and creates it.  Returns the server's representation of the duckType, and an error, if there is any.
+func (c *FakeDuckTypes) Create(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.CreateOptions) (result *v1alpha1.DuckType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(ducktypesResource, c.ns, duckType), &v1alpha1.DuckType{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DuckType), err
+}
+
+// Update takes the representation of a duckType and updates it. Returns the server's representation of the duckType, and an error, if there is any.
+func (c *FakeDuckTypes) Update(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.UpdateOptions) (result *v1alpha1.DuckType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(ducktypesResource, c.ns, duckType), &v1alpha1.DuckType{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DuckType), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDuckTypes) UpdateStatus(ctx context.Context, duckType *v1alpha1.DuckType, opts v1.UpdateOptions) (*v1alpha1.DuckType, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(ducktypesResource, "status", c.ns, duckType), &v1alpha1.DuckType{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DuckType), err
+}
+
+// Delete takes name of the duckType and deletes it. Returns an error if one occurs.
+func (c *FakeDuckTypes) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(ducktypesResource, c.ns, name), &v1alpha1.DuckType{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDuckTypes) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(ducktypesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.DuckTypeList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched duckType.
+func (c *FakeDuckTypes) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DuckType, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(ducktypesResource, c.ns, name, pt, data, subresources...), &v1alpha1.DuckType{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DuckType), err
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// FakeManuals implements ManualInterface
+type FakeManuals struct {
+	Fake *FakeDiscoveryV1alpha1
+}
+
+var manualsResource = schema.GroupVersionResource{Group: "discovery.knative.dev", Version: "v1alpha1", Resource: "manuals"}
+
+var manualsKind = schema.GroupVersionKind{Group: "discovery.knative.dev", Version: "v1alpha1", Kind: "Manual"}
+
+// Get takes name of the manual, and returns the corresponding manual object, and an error if there is any.
+func (c *FakeManuals) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Manual, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(manualsResource, name), &v1alpha1.Manual{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Manual), err
+}
+
+// List takes label and field selectors, and returns the list of Manuals that match those selectors.
+func (c *FakeManuals) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ManualList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(manualsResource, manualsKind, opts), &v1alpha1.ManualList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ManualList{ListMeta: obj.(*v1alpha1.ManualList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ManualList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested manuals.
+func (c *FakeManuals) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(manualsResource, opts))
+}
+
+// Create takes the representation of a manual and creates it.  Returns the server's representation of the manual, and an error, if there is any.
+func (c *FakeManuals) Create(ctx context.Context, manual *v1alpha1.Manual, opts v1.CreateOptions) (result *v1alpha1.Manual, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(manualsResource, manual), &v1alpha1.Manual{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Manual), err
+}
+
+// Update takes the representation of a manual and updates it. Returns the server's representation of the manual, and an error, if there is any.
+func (c *FakeManuals) Update(ctx context.Context, manual *v1alpha1.Manual, opts v1.UpdateOptions) (result *v1alpha1.Manual, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(manualsResource, manual), &v1alpha1.Manual{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Manual), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeManuals) UpdateStatus(ctx context.Context, manual *v1alpha1.Manual, opts v1.UpdateOptions) (*v1alpha1.Manual, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(manualsResource, "status", manual), &v1alpha1.Manual{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Manual), err
+}
+
+// Delete takes name of the manual and deletes it. Returns an error if one occurs.
+func (c *FakeManuals) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(manualsResource, name), &v1alpha1.Manual{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeManuals) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(manualsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ManualList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched manual.
+func (c *FakeManuals) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Manual, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(manualsResource, name, pt, data, subresources...), &v1alpha1.Manual{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Manual), err
+}
@@ -19,3 +19,7 @@ limitations under the License.
 package v1alpha1
 
 type ClusterDuckTypeExpansion interface{}
+
+type DuckTypeExpansion interface{}
+
+type ManualExpansion interface{}
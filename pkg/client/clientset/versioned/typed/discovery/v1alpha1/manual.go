@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	scheme "knative.dev/discovery/pkg/client/clientset/versioned/scheme"
+)
+
+// ManualsGetter has a method to return a ManualInterface.
+// A group's client should implement this interface.
+type ManualsGetter interface {
+	Manuals() ManualInterface
+}
+
+// ManualInterface has methods to work with Manual resources.
+type ManualInterface interface {
+	Create(ctx context.Context, manual *v1alpha1.Manual, opts v1.CreateOptions) (*v1alpha1.Manual, error)
+	Update(ctx context.Context, manual *v1alpha1.Manual, opts v1.UpdateOptions) (*v1alpha1.Manual, error)
+	UpdateStatus(ctx context.Context, manual *v1alpha1.Manual, opts v1.UpdateOptions) (*v1alpha1.Manual, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Manual, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ManualList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Manual, err error)
+	ManualExpansion
+}
+
+// manuals implements ManualInterface
+type manuals struct {
+	client rest.Interface
+}
+
+// newManuals returns a Manuals
+func newManuals(c *DiscoveryV1alpha1Client) *manuals {
+	return &manuals{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the manual, and returns the corresponding manual object, and an error if there is any.
+func (c *manuals) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Manual, err error) {
+	result = &v1alpha1.Manual{}
+	err = c.client.Get().
+		Resource("manuals").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Manuals that match those selectors.
+func (c *manuals) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ManualList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ManualList{}
+	err = c.client.Get().
+		Resource("manuals").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested manuals.
+func (c *manuals) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("manuals").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a manual and creates it.  Returns the server's representation of the manual, and an error, if there is any.
+func (c *manuals) Create(ctx context.Context, manual *v1alpha1.Manual, opts v1.CreateOptions) (result *v1alpha1.Manual, err error) {
+	result = &v1alpha1.Manual{}
+	err = c.client.Post().
+		Resource("manuals").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(manual).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a manual and updates it. Returns the server's representation of the manual, and an error, if there is any.
+func (c *manuals) Update(ctx context.Context, manual *v1alpha1.Manual, opts v1.UpdateOptions) (result *v1alpha1.Manual, err error) {
+	result = &v1alpha1.Manual{}
+	err = c.client.Put().
+		Resource("manuals").
+		Name(manual.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(manual).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *manuals) UpdateStatus(ctx context.Context, manual *v1alpha1.Manual, opts v1.UpdateOptions) (result *v1alpha1.Manual, err error) {
+	result = &v1alpha1.Manual{}
+	err = c.client.Put().
+		Resource("manuals").
+		Name(manual.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(manual).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the manual and deletes it. Returns an error if one occurs.
+func (c *manuals) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("manuals").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *manuals) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("manuals").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched manual.
+func (c *manuals) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Manual, err error) {
+	result = &v1alpha1.Manual{}
+	err = c.client.Patch(pt).
+		Resource("manuals").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -26,6 +26,10 @@ import (
 type Interface interface {
 	// ClusterDuckTypes returns a ClusterDuckTypeInformer.
 	ClusterDuckTypes() ClusterDuckTypeInformer
+	// DuckTypes returns a DuckTypeInformer.
+	DuckTypes() DuckTypeInformer
+	// Manuals returns a ManualInformer.
+	Manuals() ManualInformer
 }
 
 type version struct {
@@ -43,3 +47,13 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) ClusterDuckTypes() ClusterDuckTypeInformer {
 	return &clusterDuckTypeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// DuckTypes returns a DuckTypeInformer.
+func (v *version) DuckTypes() DuckTypeInformer {
+	return &duckTypeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Manuals returns a ManualInformer.
+func (v *version) Manuals() ManualInformer {
+	return &manualInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -24,6 +24,7 @@ import (
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 	v1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	v1beta1 "knative.dev/discovery/pkg/apis/discovery/v1beta1"
 )
 
 // GenericInformer is type of SharedIndexInformer which will locate and delegate to other
@@ -55,6 +56,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=discovery.knative.dev, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("clusterducktypes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Discovery().V1alpha1().ClusterDuckTypes().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("ducktypes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Discovery().V1alpha1().DuckTypes().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("manuals"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Discovery().V1alpha1().Manuals().Informer()}, nil
+
+		// Group=discovery.knative.dev, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("clusterducktypes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Discovery().V1beta1().ClusterDuckTypes().Informer()}, nil
 
 	}
 
@@ -34,8 +34,10 @@ import (
 	dynamic "k8s.io/client-go/dynamic"
 	rest "k8s.io/client-go/rest"
 	v1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	v1beta1 "knative.dev/discovery/pkg/apis/discovery/v1beta1"
 	versioned "knative.dev/discovery/pkg/client/clientset/versioned"
 	typeddiscoveryv1alpha1 "knative.dev/discovery/pkg/client/clientset/versioned/typed/discovery/v1alpha1"
+	typeddiscoveryv1beta1 "knative.dev/discovery/pkg/client/clientset/versioned/typed/discovery/v1beta1"
 	injection "knative.dev/pkg/injection"
 	dynamicclient "knative.dev/pkg/injection/clients/dynamicclient"
 	logging "knative.dev/pkg/logging"
@@ -237,3 +239,403 @@ func (w *wrapDiscoveryV1alpha1ClusterDuckTypeImpl) UpdateStatus(ctx context.Cont
 func (w *wrapDiscoveryV1alpha1ClusterDuckTypeImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return nil, errors.New("NYI: Watch")
 }
+
+func (w *wrapDiscoveryV1alpha1) DuckTypes(namespace string) typeddiscoveryv1alpha1.DuckTypeInterface {
+	return &wrapDiscoveryV1alpha1DuckTypeImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "discovery.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "ducktypes",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapDiscoveryV1alpha1DuckTypeImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typeddiscoveryv1alpha1.DuckTypeInterface = (*wrapDiscoveryV1alpha1DuckTypeImpl)(nil)
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) Create(ctx context.Context, in *v1alpha1.DuckType, opts v1.CreateOptions) (*v1alpha1.DuckType, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "DuckType",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.DuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.DuckType, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.DuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.DuckTypeList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.DuckTypeList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DuckType, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.DuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) Update(ctx context.Context, in *v1alpha1.DuckType, opts v1.UpdateOptions) (*v1alpha1.DuckType, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "DuckType",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.DuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) UpdateStatus(ctx context.Context, in *v1alpha1.DuckType, opts v1.UpdateOptions) (*v1alpha1.DuckType, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "DuckType",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.DuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1DuckTypeImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapDiscoveryV1alpha1) Manuals() typeddiscoveryv1alpha1.ManualInterface {
+	return &wrapDiscoveryV1alpha1ManualImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "discovery.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "manuals",
+		}),
+	}
+}
+
+type wrapDiscoveryV1alpha1ManualImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typeddiscoveryv1alpha1.ManualInterface = (*wrapDiscoveryV1alpha1ManualImpl)(nil)
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) Create(ctx context.Context, in *v1alpha1.Manual, opts v1.CreateOptions) (*v1alpha1.Manual, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "Manual",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Manual{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Manual, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Manual{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ManualList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ManualList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Manual, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Manual{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) Update(ctx context.Context, in *v1alpha1.Manual, opts v1.UpdateOptions) (*v1alpha1.Manual, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "Manual",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Manual{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) UpdateStatus(ctx context.Context, in *v1alpha1.Manual, opts v1.UpdateOptions) (*v1alpha1.Manual, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "Manual",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Manual{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1alpha1ManualImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+// DiscoveryV1beta1 retrieves the DiscoveryV1beta1Client
+func (w *wrapClient) DiscoveryV1beta1() typeddiscoveryv1beta1.DiscoveryV1beta1Interface {
+	return &wrapDiscoveryV1beta1{
+		dyn: w.dyn,
+	}
+}
+
+type wrapDiscoveryV1beta1 struct {
+	dyn dynamic.Interface
+}
+
+func (w *wrapDiscoveryV1beta1) RESTClient() rest.Interface {
+	panic("RESTClient called on dynamic client!")
+}
+
+func (w *wrapDiscoveryV1beta1) ClusterDuckTypes() typeddiscoveryv1beta1.ClusterDuckTypeInterface {
+	return &wrapDiscoveryV1beta1ClusterDuckTypeImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "discovery.knative.dev",
+			Version:  "v1beta1",
+			Resource: "clusterducktypes",
+		}),
+	}
+}
+
+type wrapDiscoveryV1beta1ClusterDuckTypeImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typeddiscoveryv1beta1.ClusterDuckTypeInterface = (*wrapDiscoveryV1beta1ClusterDuckTypeImpl)(nil)
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) Create(ctx context.Context, in *v1beta1.ClusterDuckType, opts v1.CreateOptions) (*v1beta1.ClusterDuckType, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1beta1",
+		Kind:    "ClusterDuckType",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.ClusterDuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.ClusterDuckType, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.ClusterDuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) List(ctx context.Context, opts v1.ListOptions) (*v1beta1.ClusterDuckTypeList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.ClusterDuckTypeList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterDuckType, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.ClusterDuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) Update(ctx context.Context, in *v1beta1.ClusterDuckType, opts v1.UpdateOptions) (*v1beta1.ClusterDuckType, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1beta1",
+		Kind:    "ClusterDuckType",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.ClusterDuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) UpdateStatus(ctx context.Context, in *v1beta1.ClusterDuckType, opts v1.UpdateOptions) (*v1beta1.ClusterDuckType, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "discovery.knative.dev",
+		Version: "v1beta1",
+		Kind:    "ClusterDuckType",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.ClusterDuckType{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapDiscoveryV1beta1ClusterDuckTypeImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	v1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// DuckTypeLister helps list DuckTypes.
+// All objects returned here must be treated as read-only.
+type DuckTypeLister interface {
+	// List lists all DuckTypes in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DuckType, err error)
+	// DuckTypes returns an object that can list and get DuckTypes.
+	DuckTypes(namespace string) DuckTypeNamespaceLister
+	DuckTypeListerExpansion
+}
+
+// duckTypeLister implements the DuckTypeLister interface.
+type duckTypeLister struct {
+	indexer cache.Indexer
+}
+
+// NewDuckTypeLister returns a new DuckTypeLister.
+func NewDuckTypeLister(indexer cache.Indexer) DuckTypeLister {
+	return &duckTypeLister{indexer: indexer}
+}
+
+// List lists all DuckTypes in the indexer.
+func (s *duckTypeLister) List(selector labels.Selector) (ret []*v1alpha1.DuckType, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DuckType))
+	})
+	return ret, err
+}
+
+// DuckTypes returns an object that can list and get DuckTypes.
+func (s *duckTypeLister) DuckTypes(namespace string) DuckTypeNamespaceLister {
+	return duckTypeNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DuckTypeNamespaceLister helps list and get DuckTypes.
+// All objects returned here must be treated as read-only.
+type DuckTypeNamespaceLister interface {
+	// List lists all DuckTypes in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DuckType, err error)
+	// Get retrieves the DuckType from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.DuckType, error)
+	DuckTypeNamespaceListerExpansion
+}
+
+// duckTypeNamespaceLister implements the DuckTypeNamespaceLister
+// interface.
+type duckTypeNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DuckTypes in the indexer for a given namespace.
+func (s duckTypeNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.DuckType, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DuckType))
+	})
+	return ret, err
+}
+
+// Get retrieves the DuckType from the indexer for a given namespace and name.
+func (s duckTypeNamespaceLister) Get(name string) (*v1alpha1.DuckType, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("ducktype"), name)
+	}
+	return obj.(*v1alpha1.DuckType), nil
+}
@@ -21,3 +21,15 @@ package v1alpha1
 // ClusterDuckTypeListerExpansion allows custom methods to be added to
 // ClusterDuckTypeLister.
 type ClusterDuckTypeListerExpansion interface{}
+
+// DuckTypeListerExpansion allows custom methods to be added to
+// DuckTypeLister.
+type DuckTypeListerExpansion interface{}
+
+// DuckTypeNamespaceListerExpansion allows custom methods to be added to
+// DuckTypeNamespaceLister.
+type DuckTypeNamespaceListerExpansion interface{}
+
+// ManualListerExpansion allows custom methods to be added to
+// ManualLister.
+type ManualListerExpansion interface{}
@@ -34,3 +34,15 @@ func (a ByResourceMeta) Less(i, j int) bool {
 	keyJ := fmt.Sprintf("%s-%s", a[j].APIVersion, a[j].Kind)
 	return keyI < keyJ
 }
+
+// ByNonConformingResourceMeta implements sort.Interface for
+// []v1alpha1.NonConformingResourceMeta based on the group and resource fields.
+type ByNonConformingResourceMeta []v1alpha1.NonConformingResourceMeta
+
+func (a ByNonConformingResourceMeta) Len() int      { return len(a) }
+func (a ByNonConformingResourceMeta) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a ByNonConformingResourceMeta) Less(i, j int) bool {
+	keyI := fmt.Sprintf("%s-%s", a[i].APIVersion, a[i].Kind)
+	keyJ := fmt.Sprintf("%s-%s", a[j].APIVersion, a[j].Kind)
+	return keyI < keyJ
+}
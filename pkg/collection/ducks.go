@@ -23,6 +23,9 @@ import (
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubeversion "k8s.io/apimachinery/pkg/version"
 	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
 )
 
@@ -45,8 +48,26 @@ type DuckHunter interface {
 	// TODO: if the ref is a CRD, load the CRD and pass that CRD to AddCRD.
 	AddRef(duckVersion string, ref v1alpha1.ResourceRef) error
 
+	// RefScopeMismatches returns the refs added to the hunter whose scope
+	// differs from the scope of their resource in the cluster. The ducks of
+	// those refs have the scope of the cluster.
+	RefScopeMismatches() []RefScopeMismatch
+
+	// AddOpenAPIKinds adds the kinds that conform to the schema of a duck
+	// version to the ducks of that version. Duck versions whose schema has no
+	// properties are skipped, as every kind would conform.
+	AddOpenAPIKinds(kinds []OpenAPIKind)
+
 	// Ducks returns the current mapped collection of ducks added to the hunter.
 	Ducks() map[string][]v1alpha1.ResourceMeta
+
+	// NonConformingDucks returns the resources added to the hunter that do
+	// not satisfy the schema of the duck version they were mapped to.
+	NonConformingDucks() map[string][]v1alpha1.NonConformingResourceMeta
+
+	// SkippedCRDs returns the CRDs added to the hunter that were not hunted
+	// because they are not established or their names were not accepted.
+	SkippedCRDs() []v1alpha1.SkippedCRD
 }
 
 type DuckFilters struct {
@@ -75,7 +96,7 @@ func NewDuckHunter(mapper ResourceMapper, defaultVersions []v1alpha1.DuckVersion
 		defaultVersions:         make([]string, 0),
 		ducks:                   make(map[string][]v1alpha1.ResourceMeta, len(defaultVersions)),
 		accesbileGroupresources: accessibleGroupResources(expectedVerbsForAccess, clusterRole),
-		kindToResource:          make(map[string]string),
+		kindToResource:          make(map[schema.GroupKind]string),
 	}
 
 	for _, v := range defaultVersions {
@@ -83,6 +104,12 @@ func NewDuckHunter(mapper ResourceMapper, defaultVersions []v1alpha1.DuckVersion
 			dh.defaultVersions = append(dh.defaultVersions, v.Name)
 			dh.ducks[v.Name] = make([]v1alpha1.ResourceMeta, 0)
 		}
+		if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil {
+			if dh.schemas == nil {
+				dh.schemas = make(map[string]*apiextensionsv1.JSONSchemaProps)
+			}
+			dh.schemas[v.Name] = v.Schema.OpenAPIV3Schema
+		}
 	}
 
 	return dh
@@ -99,7 +126,63 @@ type duckHunter struct {
 	defaultVersions         []string
 	ducks                   map[string][]v1alpha1.ResourceMeta
 	accesbileGroupresources map[string]bool
-	kindToResource          map[string]string
+	kindToResource          map[schema.GroupKind]string
+	// schemas holds the partial schema of each duck version that has one.
+	schemas map[string]*apiextensionsv1.JSONSchemaProps
+	// nonConforming holds the CRD versions that failed the schema check of
+	// the duck version they were mapped to.
+	nonConforming map[string][]v1alpha1.NonConformingResourceMeta
+	// skipped holds the CRDs that are not served as they are described.
+	skipped []v1alpha1.SkippedCRD
+	// misscoped holds the refs whose scope differs from the cluster.
+	misscoped []RefScopeMismatch
+}
+
+// RefScopeMismatch is a ref whose scope differs from the scope of its
+// resource in the cluster.
+type RefScopeMismatch struct {
+	// DuckVersion is the duck version the ref was added to.
+	DuckVersion string
+	// Ref is the ref as given.
+	Ref v1alpha1.ResourceRef
+	// Kind is the kind of the resource of the ref.
+	Kind string
+	// Scope is the scope of the resource in the cluster.
+	Scope v1alpha1.ResourceScope
+}
+
+// String implements fmt.Stringer
+func (m RefScopeMismatch) String() string {
+	return fmt.Sprintf("%s: %s %s has scope %s, not %s", m.DuckVersion, m.Kind, m.Ref.GroupVersion(), m.Scope, m.Ref.Scope)
+}
+
+// RefScopeMismatchesMessage joins the mismatches in a message.
+func RefScopeMismatchesMessage(mismatches []RefScopeMismatch) string {
+	msgs := make([]string, 0, len(mismatches))
+	for _, m := range mismatches {
+		msgs = append(msgs, m.String())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// RefScope returns the scope of the resource of the ref in the cluster, and
+// false if the resource is not known.
+func RefScope(mapper ResourceMapper, ref v1alpha1.ResourceRef) (v1alpha1.ResourceScope, bool) {
+	resource := ref.Resource
+	if resource == "" {
+		var err error
+		if resource, err = mapper.ResourceFor(ref.GroupVersion(), ref.Kind); err != nil {
+			return "", false
+		}
+	}
+	info, err := mapper.InfoFor(ref.GroupVersion(), resource)
+	if err != nil {
+		return "", false
+	}
+	if info.Namespaced {
+		return v1alpha1.NamespaceScoped, true
+	}
+	return v1alpha1.ClusterScoped, true
 }
 
 // AddCRDs implements DuckHunter.AddCRDs
@@ -114,6 +197,14 @@ func (dh *duckHunter) AddCRD(crd *apiextensionsv1.CustomResourceDefinition) {
 	if crd == nil {
 		return
 	}
+	if reason, message := skipReason(crd); reason != "" {
+		dh.skipped = append(dh.skipped, v1alpha1.SkippedCRD{
+			Name:    crd.Name,
+			Reason:  reason,
+			Message: message,
+		})
+		return
+	}
 	if metas := crdToResourceMeta(crd); len(metas) > 0 {
 		dh.collectVersionsByFilter(crd)
 		for _, meta := range metas {
@@ -121,11 +212,11 @@ func (dh *duckHunter) AddCRD(crd *apiextensionsv1.CustomResourceDefinition) {
 				// If not handled within the filter aware handler, then apply
 				// this resource to all the default duck versions.
 				for _, v := range dh.defaultVersions {
-					dh.ducks[v] = append(dh.ducks[v], meta)
+					dh.insertCRDDuck(v, crd, meta)
 				}
 			}
 		}
-		dh.kindToResource[crd.Spec.Names.Kind] = crd.Spec.Names.Plural
+		dh.kindToResource[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Status.AcceptedNames.Kind}] = crd.Status.AcceptedNames.Plural
 	}
 }
 
@@ -170,6 +261,8 @@ func (dh *duckHunter) collectVersionsByFilter(crd *apiextensionsv1.CustomResourc
 
 // insertHandledDuckByVersionFilter holds the logic to map a CRD to a duck type
 // version based on the duck type version annotations, if present.
+// Annotations are the only place for the mapping, vendor extensions in the
+// schema of a CRD version are dropped by the API server.
 func (dh *duckHunter) insertHandledDuckByVersionFilter(crd *apiextensionsv1.CustomResourceDefinition, meta v1alpha1.ResourceMeta) (handled bool) {
 	if dh.filters == nil || dh.filters.DuckVersionPrefix == "" {
 		return false
@@ -184,7 +277,7 @@ func (dh *duckHunter) insertHandledDuckByVersionFilter(crd *apiextensionsv1.Cust
 				v = strings.TrimSpace(v)
 				if v == version(meta) {
 					duckVersion := strings.TrimPrefix(k, dh.filters.DuckVersionPrefix+"/")
-					dh.ducks[duckVersion] = append(dh.ducks[duckVersion], meta)
+					dh.insertCRDDuck(duckVersion, crd, meta)
 				}
 			}
 		}
@@ -192,6 +285,23 @@ func (dh *duckHunter) insertHandledDuckByVersionFilter(crd *apiextensionsv1.Cust
 	return
 }
 
+// insertCRDDuck adds the CRD version described by meta to the ducks of the
+// given duck version, or to the non-conforming ducks if the CRD version schema
+// does not satisfy the schema of the duck version.
+func (dh *duckHunter) insertCRDDuck(duckVersion string, crd *apiextensionsv1.CustomResourceDefinition, meta v1alpha1.ResourceMeta) {
+	if mismatches := SchemaMismatches(dh.schemas[duckVersion], crdVersionSchema(crd, version(meta))); len(mismatches) > 0 {
+		if dh.nonConforming == nil {
+			dh.nonConforming = make(map[string][]v1alpha1.NonConformingResourceMeta)
+		}
+		dh.nonConforming[duckVersion] = append(dh.nonConforming[duckVersion], v1alpha1.NonConformingResourceMeta{
+			ResourceMeta:     meta,
+			MismatchedFields: mismatches,
+		})
+		return
+	}
+	dh.ducks[duckVersion] = append(dh.ducks[duckVersion], meta)
+}
+
 // AddRef implements DuckHunter.AddRef
 func (dh *duckHunter) AddRef(duckVersion string, ref v1alpha1.ResourceRef) error {
 	// Use ref.Kind or look up the kind based on ref.Resource.
@@ -210,13 +320,35 @@ func (dh *duckHunter) AddRef(duckVersion string, ref v1alpha1.ResourceRef) error
 		Scope:      ref.Scope,
 	}
 
-	dh.kindToResource[kind] = ref.Resource
+	// Use ref.Resource or look up the resource based on the kind.
+	resource := ref.Resource
+	if resource == "" {
+		// Not found is reported by the kind check below.
+		resource, _ = dh.mapper.ResourceFor(rm.APIVersion, kind)
+	}
+	dh.kindToResource[groupKind(rm)] = resource
 
 	// Validate that the resource exists in this cluster.
 	if !dh.mapper.KindExists(rm.APIVersion, rm.Kind) {
 		return fmt.Errorf("resource \"%s %s\" not known to the cluster", rm.Kind, rm.APIVersion)
 	}
 
+	// The scope of the duck is taken from the cluster, a scope set on the ref
+	// that differs is reported. A duck is not published without a scope.
+	if scope, found := RefScope(dh.mapper, ref); !found && ref.Scope == "" {
+		return fmt.Errorf("scope of resource \"%s %s\" not known to the cluster, set it on the ref", rm.Kind, rm.APIVersion)
+	} else if found {
+		if ref.Scope != "" && scope != ref.Scope {
+			dh.misscoped = append(dh.misscoped, RefScopeMismatch{
+				DuckVersion: duckVersion,
+				Ref:         ref,
+				Kind:        rm.Kind,
+				Scope:       scope,
+			})
+		}
+		rm.Scope = scope
+	}
+
 	// Save the resource at the given duck type version, making sure there is
 	// a place to store it.
 	if _, found := dh.ducks[duckVersion]; !found {
@@ -228,6 +360,54 @@ func (dh *duckHunter) AddRef(duckVersion string, ref v1alpha1.ResourceRef) error
 	return nil
 }
 
+// AddOpenAPIKinds implements DuckHunter.AddOpenAPIKinds
+func (dh *duckHunter) AddOpenAPIKinds(kinds []OpenAPIKind) {
+	versions := make([]string, 0, len(dh.schemas))
+	for v, s := range dh.schemas {
+		if len(s.Properties) > 0 {
+			versions = append(versions, v)
+		}
+	}
+	sort.Strings(versions)
+
+	for i := range kinds {
+		k := &kinds[i]
+		for _, v := range versions {
+			// A kind already added, by ref, is not added again.
+			if hasDuck(dh.ducks[v], k.GroupVersionKind) || len(k.Mismatches(dh.schemas[v])) > 0 {
+				continue
+			}
+			dh.ducks[v] = append(dh.ducks[v], v1alpha1.ResourceMeta{
+				APIVersion: k.GroupVersion().String(),
+				Kind:       k.Kind,
+				Scope:      k.Scope,
+				Source:     v1alpha1.OpenAPISource,
+			})
+			dh.kindToResource[k.GroupKind()] = k.Resource
+		}
+	}
+}
+
+// hasDuck tells whether the ducks hold the group version kind.
+func hasDuck(metas []v1alpha1.ResourceMeta, gvk schema.GroupVersionKind) bool {
+	for _, meta := range metas {
+		if meta.APIVersion == gvk.GroupVersion().String() && meta.Kind == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// RefScopeMismatches implements DuckHunter.RefScopeMismatches
+func (dh *duckHunter) RefScopeMismatches() []RefScopeMismatch {
+	if len(dh.misscoped) == 0 {
+		return nil
+	}
+	mismatches := make([]RefScopeMismatch, len(dh.misscoped))
+	copy(mismatches, dh.misscoped)
+	return mismatches
+}
+
 // duckCopy makes a deep copy of the ducks map
 func duckCopy(d map[string][]v1alpha1.ResourceMeta) map[string][]v1alpha1.ResourceMeta {
 	ducks := make(map[string][]v1alpha1.ResourceMeta, len(d))
@@ -247,7 +427,10 @@ func (dh *duckHunter) Ducks() map[string][]v1alpha1.ResourceMeta {
 			delete(ducks, k)
 		} else {
 			sort.Sort(ByResourceMeta(ducks[k]))
+			setResource(ducks[k], dh.kindToResource)
+			setCapabilities(ducks[k], dh.mapper)
 			setAccessibleViaClusterRole(ducks[k], dh.accesbileGroupresources, dh.kindToResource)
+			setPreferred(ducks[k], dh.mapper)
 		}
 	}
 	if len(ducks) == 0 {
@@ -256,12 +439,100 @@ func (dh *duckHunter) Ducks() map[string][]v1alpha1.ResourceMeta {
 	return ducks
 }
 
+// NonConformingDucks implements DuckHunter.NonConformingDucks
+func (dh *duckHunter) NonConformingDucks() map[string][]v1alpha1.NonConformingResourceMeta {
+	if len(dh.nonConforming) == 0 {
+		return nil
+	}
+	ducks := make(map[string][]v1alpha1.NonConformingResourceMeta, len(dh.nonConforming))
+	for k, v := range dh.nonConforming {
+		vc := make([]v1alpha1.NonConformingResourceMeta, len(v))
+		for i := range v {
+			vc[i] = *v[i].DeepCopy()
+			vc[i].Resource = dh.kindToResource[groupKind(vc[i].ResourceMeta)]
+		}
+		sort.Sort(ByNonConformingResourceMeta(vc))
+		ducks[k] = vc
+	}
+	return ducks
+}
+
+// SkippedCRDs implements DuckHunter.SkippedCRDs
+func (dh *duckHunter) SkippedCRDs() []v1alpha1.SkippedCRD {
+	if len(dh.skipped) == 0 {
+		return nil
+	}
+	skipped := make([]v1alpha1.SkippedCRD, len(dh.skipped))
+	copy(skipped, dh.skipped)
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].Name < skipped[j].Name
+	})
+	return skipped
+}
+
+// setResource sets the plural resource name on each duck.
+func setResource(metas []v1alpha1.ResourceMeta, kindToResource map[schema.GroupKind]string) {
+	for index, meta := range metas {
+		metas[index].Resource = kindToResource[groupKind(meta)]
+	}
+}
+
+// reportedSubresources are the subresources reported on the ducks.
+var reportedSubresources = sets.NewString("scale", "status")
+
+// setCapabilities sets the verbs and the status and scale subresources of each
+// duck, as listed by discovery.
+func setCapabilities(metas []v1alpha1.ResourceMeta, mapper ResourceMapper) {
+	for index, meta := range metas {
+		info, err := mapper.InfoFor(meta.APIVersion, meta.Resource)
+		if err != nil {
+			continue
+		}
+		metas[index].Verbs = info.Verbs
+		if subs := sets.NewString(info.Subresources...).Intersection(reportedSubresources); subs.Len() > 0 {
+			metas[index].Subresources = subs.List()
+		}
+	}
+}
+
+// setPreferred marks the preferred version of each kind of duck: the version
+// discovery prefers for its group, if the kind is a duck in it. Otherwise,
+// versions that are not deprecated are preferred, then the highest version.
+func setPreferred(metas []v1alpha1.ResourceMeta, mapper ResourceMapper) {
+	preferred := make(map[string]int)
+	for index, meta := range metas {
+		metas[index].Preferred = false
+		key := strings.ToLower(fmt.Sprintf("%s:%s", group(meta), meta.Kind))
+		best, found := preferred[key]
+		if !found || prefer(meta, metas[best], mapper) {
+			preferred[key] = index
+		}
+	}
+	for _, index := range preferred {
+		metas[index].Preferred = true
+	}
+}
+
+// prefer reports whether the version of a is preferred over the version of b,
+// two versions of the same kind.
+func prefer(a, b v1alpha1.ResourceMeta, mapper ResourceMapper) bool {
+	if version, found := mapper.PreferredVersion(group(a)); found && a.Version() != b.Version() {
+		if a.Version() == version || b.Version() == version {
+			return a.Version() == version
+		}
+	}
+	if a.Deprecated != b.Deprecated {
+		return !a.Deprecated
+	}
+	return kubeversion.CompareKubeAwareVersionStrings(a.Version(), b.Version()) > 0
+}
+
 // setAccessibleViaClusterRole sets the AccessibleViaClusterRole flag on each duck if
 //   the ClusterRole can preform the expected verbs on the duck
-func setAccessibleViaClusterRole(metas []v1alpha1.ResourceMeta, accessibleGroupResources map[string]bool, kindToResource map[string]string) {
+func setAccessibleViaClusterRole(metas []v1alpha1.ResourceMeta, accessibleGroupResources map[string]bool, kindToResource map[schema.GroupKind]string) {
 	for index, meta := range metas {
 		// TODO: it would be nice if ResourceMeta had a version-free unique hash to do this.
-		if resource, ok := kindToResource[meta.Kind]; ok {
+		if resource, ok := kindToResource[groupKind(meta)]; ok {
 			key := strings.ToLower(fmt.Sprintf("%s:%s", group(meta), resource))
 			wildcardKey := "*:*"
 			wildcardAPIGroupKey := strings.ToLower(fmt.Sprintf("*:%s", resource))
@@ -293,7 +564,44 @@ func group(meta v1alpha1.ResourceMeta) string {
 	return ""
 }
 
-// crdToResourceMeta takes in a CRD and converts it to a set of ResourceMeta.
+// groupKind returns the group and kind of a ResourceMeta, kinds are only
+// unique within a group.
+func groupKind(meta v1alpha1.ResourceMeta) schema.GroupKind {
+	return schema.GroupKind{Group: group(meta), Kind: meta.Kind}
+}
+
+// skipReason returns why the CRD is not served as it is described, or an
+// empty reason if it can be hunted. A CRD is served once it is established,
+// using the names that were accepted, which lag behind the spec when new
+// names conflict with another CRD.
+func skipReason(crd *apiextensionsv1.CustomResourceDefinition) (reason, message string) {
+	var established, namesAccepted *apiextensionsv1.CustomResourceDefinitionCondition
+	for i := range crd.Status.Conditions {
+		switch crd.Status.Conditions[i].Type {
+		case apiextensionsv1.Established:
+			established = &crd.Status.Conditions[i]
+		case apiextensionsv1.NamesAccepted:
+			namesAccepted = &crd.Status.Conditions[i]
+		}
+	}
+
+	switch {
+	case established != nil && established.Status == apiextensionsv1.ConditionTrue:
+		if crd.Status.AcceptedNames.Kind == "" || crd.Status.AcceptedNames.Plural == "" {
+			return "NamesNotAccepted", "The CRD is established without accepted names"
+		}
+		return "", ""
+	case namesAccepted != nil && namesAccepted.Status == apiextensionsv1.ConditionFalse:
+		return "NamesNotAccepted", namesAccepted.Message
+	case established != nil && established.Message != "":
+		return "NotEstablished", established.Message
+	default:
+		return "NotEstablished", "The CRD is not established yet"
+	}
+}
+
+// crdToResourceMeta takes in a CRD and converts it to a set of ResourceMeta,
+// using the accepted names of the CRD.
 func crdToResourceMeta(crd *apiextensionsv1.CustomResourceDefinition) []v1alpha1.ResourceMeta {
 	metas := make([]v1alpha1.ResourceMeta, 0)
 	for _, v := range crd.Spec.Versions {
@@ -301,14 +609,17 @@ func crdToResourceMeta(crd *apiextensionsv1.CustomResourceDefinition) []v1alpha1
 			continue
 		}
 
-		// TODO: this will have issues for unaccepted CRDs
-		// We need to look at the combo of crd.spec and crd.status
-		// for this metadata.
-		metas = append(metas, v1alpha1.ResourceMeta{
+		meta := v1alpha1.ResourceMeta{
 			APIVersion: apiVersion(crd.Spec.Group, v.Name),
-			Kind:       crd.Spec.Names.Kind,
+			Kind:       crd.Status.AcceptedNames.Kind,
 			Scope:      v1alpha1.ResourceScope(crd.Spec.Scope),
-		})
+			Storage:    v.Storage,
+			Deprecated: v.Deprecated,
+		}
+		if v.DeprecationWarning != nil {
+			meta.DeprecationWarning = *v.DeprecationWarning
+		}
+		metas = append(metas, meta)
 	}
 	return metas
 }
@@ -50,7 +50,17 @@ func makeCRD(group, kind string, versions map[string]bool) *apiextensionsv1.Cust
 			Conversion:            nil,
 			PreserveUnknownFields: false,
 		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{{
+				Type:   apiextensionsv1.Established,
+				Status: apiextensionsv1.ConditionTrue,
+			}, {
+				Type:   apiextensionsv1.NamesAccepted,
+				Status: apiextensionsv1.ConditionTrue,
+			}},
+		},
 	}
+	crd.Status.AcceptedNames = crd.Spec.Names
 
 	for name, served := range versions {
 		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
@@ -70,6 +80,25 @@ func makeCRDAnnotated(group, kind string, versions map[string]bool, labels, anno
 	return crd
 }
 
+func withStorage(crd *apiextensionsv1.CustomResourceDefinition, version string) *apiextensionsv1.CustomResourceDefinition {
+	for i := range crd.Spec.Versions {
+		crd.Spec.Versions[i].Storage = crd.Spec.Versions[i].Name == version
+	}
+	return crd
+}
+
+func withDeprecated(crd *apiextensionsv1.CustomResourceDefinition, version, warning string) *apiextensionsv1.CustomResourceDefinition {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == version {
+			crd.Spec.Versions[i].Deprecated = true
+			if warning != "" {
+				crd.Spec.Versions[i].DeprecationWarning = &warning
+			}
+		}
+	}
+	return crd
+}
+
 func TestNewDuckHunter(t *testing.T) {
 	tests := map[string]struct {
 		mapper   ResourceMapper
@@ -82,7 +111,7 @@ func TestNewDuckHunter(t *testing.T) {
 				defaultVersions:         []string{},
 				ducks:                   map[string][]v1alpha1.ResourceMeta{},
 				accesbileGroupresources: map[string]bool{},
-				kindToResource:          map[string]string{},
+				kindToResource:          map[schema.GroupKind]string{},
 			},
 		},
 		"one defaultVersions": {
@@ -96,7 +125,7 @@ func TestNewDuckHunter(t *testing.T) {
 					"v1": {},
 				},
 				accesbileGroupresources: map[string]bool{},
-				kindToResource:          map[string]string{},
+				kindToResource:          map[schema.GroupKind]string{},
 			},
 		},
 		"non nil mapper": {
@@ -125,7 +154,7 @@ func TestNewDuckHunter(t *testing.T) {
 					"v1": {},
 				},
 				accesbileGroupresources: map[string]bool{},
-				kindToResource:          map[string]string{},
+				kindToResource:          map[schema.GroupKind]string{},
 			},
 		},
 		"three defaultVersions": {
@@ -139,7 +168,7 @@ func TestNewDuckHunter(t *testing.T) {
 					"v3": {},
 				},
 				accesbileGroupresources: map[string]bool{},
-				kindToResource:          map[string]string{},
+				kindToResource:          map[schema.GroupKind]string{},
 			},
 		},
 		"overlapping defaultVersions": {
@@ -154,7 +183,7 @@ func TestNewDuckHunter(t *testing.T) {
 					"v2": {},
 				},
 				accesbileGroupresources: map[string]bool{},
-				kindToResource:          map[string]string{},
+				kindToResource:          map[schema.GroupKind]string{},
 			},
 		}}
 	for name, tc := range tests {
@@ -179,7 +208,9 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -196,8 +227,10 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion:               "teach.me.how/v2",
 					Kind:                     "Ducky",
+					Resource:                 "duckies",
 					Scope:                    "Namespaced",
 					AccessibleViaClusterRole: true,
+					Preferred:                true,
 				}},
 			},
 		},
@@ -214,8 +247,10 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion:               "teach.me.how/v2",
 					Kind:                     "Ducky",
+					Resource:                 "duckies",
 					Scope:                    "Namespaced",
 					AccessibleViaClusterRole: true,
+					Preferred:                true,
 				}},
 			},
 		},
@@ -232,8 +267,10 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion:               "teach.me.how/v2",
 					Kind:                     "Ducky",
+					Resource:                 "duckies",
 					Scope:                    "Namespaced",
 					AccessibleViaClusterRole: true,
+					Preferred:                true,
 				}},
 			},
 		},
@@ -250,8 +287,10 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion:               "teach.me.how/v2",
 					Kind:                     "Ducky",
+					Resource:                 "duckies",
 					Scope:                    "Namespaced",
 					AccessibleViaClusterRole: true,
+					Preferred:                true,
 				}},
 			},
 		},
@@ -268,8 +307,10 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion:               "teach.me.how/v2",
 					Kind:                     "Ducky",
+					Resource:                 "duckies",
 					Scope:                    "Namespaced",
 					AccessibleViaClusterRole: false,
+					Preferred:                true,
 				}},
 			},
 		},
@@ -280,11 +321,35 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
 				}, {
 					APIVersion: "teach.me.how/v3",
 					Kind:       "Ducky",
+					Resource:   "duckies",
+					Scope:      "Namespaced",
+					Preferred:  true,
+				}},
+			},
+		},
+		"deprecated crd version is not preferred": {
+			dh:  NewDuckHunter(nil, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil),
+			crd: withDeprecated(withStorage(makeCRD("teach.me.how", "Ducky", map[string]bool{"v1beta1": true, "v2": true}), "v1beta1"), "v2", "use v1beta1"),
+			want: map[string][]v1alpha1.ResourceMeta{
+				"v1": {{
+					APIVersion: "teach.me.how/v1beta1",
+					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Storage:    true,
+					Preferred:  true,
+				}, {
+					APIVersion:         "teach.me.how/v2",
+					Kind:               "Ducky",
+					Resource:           "duckies",
+					Scope:              "Namespaced",
+					Deprecated:         true,
+					DeprecationWarning: "use v1beta1",
 				}},
 			},
 		},
@@ -295,17 +360,23 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 				"v2": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 				"v3": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -320,6 +391,35 @@ func Test_DuckHunter_AddCRD(t *testing.T) {
 	}
 }
 
+func Test_DuckHunter_AddCRD_sameKind(t *testing.T) {
+	// The same kind in two groups keeps the resource of each group.
+	dh := NewDuckHunter(nil, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil)
+	dh.AddCRD(makeCRD("teach.me.how", "Ducky", map[string]bool{"v1": true}))
+	other := makeCRD("other.pond", "Ducky", map[string]bool{"v1": true})
+	other.Spec.Names.Plural = "duckys"
+	other.Status.AcceptedNames.Plural = "duckys"
+	dh.AddCRD(other)
+
+	want := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "other.pond/v1",
+			Kind:       "Ducky",
+			Resource:   "duckys",
+			Scope:      "Namespaced",
+			Preferred:  true,
+		}, {
+			APIVersion: "teach.me.how/v1",
+			Kind:       "Ducky",
+			Resource:   "duckies",
+			Scope:      "Namespaced",
+			Preferred:  true,
+		}},
+	}
+	if got := dh.Ducks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Ducks() = %v, want %v", got, want)
+	}
+}
+
 func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 	tests := map[string]struct {
 		dh   DuckHunter
@@ -337,7 +437,9 @@ func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -353,16 +455,21 @@ func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/blue",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}, {
 					APIVersion: "teach.me.how/red",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
 				}},
 				"v2": {{
 					APIVersion: "teach.me.how/green",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -377,7 +484,9 @@ func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -400,7 +509,9 @@ func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -414,7 +525,9 @@ func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -429,7 +542,9 @@ func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -444,12 +559,16 @@ func Test_DuckHunter_AddCRD_filtered(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 				"v1swag": {{
 					APIVersion: "teach.me.how/v1",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -480,16 +599,38 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 			APIResources: []metav1.APIResource{{
 				Kind:       "Ducky",
 				Name:       "duckies",
+				Namespaced: true,
+			}, {
+				Kind:       "Pond",
+				Name:       "ponds",
 				Namespaced: false,
 			}},
+		}, {
+			GroupVersion: "swan.lake/v1",
+			APIResources: []metav1.APIResource{{
+				Kind:       "Ballet",
+				Name:       "ballets",
+				Namespaced: true,
+				Verbs:      []string{"get", "list", "watch"},
+			}, {
+				Kind: "Ballet",
+				Name: "ballets/status",
+			}, {
+				Kind: "Ballet",
+				Name: "ballets/finalizers",
+			}, {
+				Kind: "Scale",
+				Name: "ballets/scale",
+			}},
 		}})
 
 	tests := map[string]struct {
-		dh          DuckHunter
-		duckVersion string
-		ref         v1alpha1.ResourceRef
-		want        map[string][]v1alpha1.ResourceMeta
-		wantErr     bool
+		dh             DuckHunter
+		duckVersion    string
+		ref            v1alpha1.ResourceRef
+		want           map[string][]v1alpha1.ResourceMeta
+		wantMismatches []RefScopeMismatch
+		wantErr        bool
 	}{
 		"GVK, no default duck type version": {
 			dh:          NewDuckHunter(mapper, nil, nil, nil),
@@ -504,7 +645,9 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -521,7 +664,9 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -545,8 +690,10 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 				"v1": {{
 					APIVersion:               "teach.me.how/v2",
 					Kind:                     "Ducky",
+					Resource:                 "Duckies",
 					Scope:                    "Namespaced",
 					AccessibleViaClusterRole: true,
+					Preferred:                true,
 				}},
 			},
 		},
@@ -569,7 +716,9 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -586,7 +735,9 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -602,7 +753,9 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
@@ -618,10 +771,102 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 				"v1": {{
 					APIVersion: "teach.me.how/v2",
 					Kind:       "Ducky",
+					Resource:   "duckies",
 					Scope:      "Namespaced",
+					Preferred:  true,
 				}},
 			},
 		},
+		"GVK, duck with verbs and subresources": {
+			dh:          NewDuckHunter(mapper, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil),
+			duckVersion: "v1",
+			ref: v1alpha1.ResourceRef{
+				Group:   "swan.lake",
+				Version: "v1",
+				Kind:    "Ballet",
+				Scope:   "Namespaced",
+			},
+			want: map[string][]v1alpha1.ResourceMeta{
+				"v1": {{
+					APIVersion:   "swan.lake/v1",
+					Kind:         "Ballet",
+					Resource:     "ballets",
+					Scope:        "Namespaced",
+					Preferred:    true,
+					Verbs:        []string{"get", "list", "watch"},
+					Subresources: []string{"scale", "status"},
+				}},
+			},
+		},
+		"GVK, cluster scoped resource": {
+			dh:          NewDuckHunter(mapper, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil),
+			duckVersion: "v1",
+			ref: v1alpha1.ResourceRef{
+				Group:   "teach.me.how",
+				Version: "v2",
+				Kind:    "Pond",
+				Scope:   "Namespaced",
+			},
+			want: map[string][]v1alpha1.ResourceMeta{
+				"v1": {{
+					APIVersion: "teach.me.how/v2",
+					Kind:       "Pond",
+					Resource:   "ponds",
+					Scope:      "Cluster",
+					Preferred:  true,
+				}},
+			},
+			wantMismatches: []RefScopeMismatch{{
+				DuckVersion: "v1",
+				Ref: v1alpha1.ResourceRef{
+					Group:   "teach.me.how",
+					Version: "v2",
+					Kind:    "Pond",
+					Scope:   "Namespaced",
+				},
+				Kind:  "Pond",
+				Scope: "Cluster",
+			}},
+		},
+		"GVK, cluster scoped resource without a scope": {
+			dh:          NewDuckHunter(mapper, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil),
+			duckVersion: "v1",
+			ref: v1alpha1.ResourceRef{
+				Group:   "teach.me.how",
+				Version: "v2",
+				Kind:    "Pond",
+			},
+			want: map[string][]v1alpha1.ResourceMeta{
+				"v1": {{
+					APIVersion: "teach.me.how/v2",
+					Kind:       "Pond",
+					Resource:   "ponds",
+					Scope:      "Cluster",
+					Preferred:  true,
+				}},
+			},
+		},
+		"GVK, resource of unknown scope without a scope": {
+			dh:          NewDuckHunter(mapper, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil),
+			duckVersion: "v1",
+			ref: v1alpha1.ResourceRef{
+				Group:    "teach.me.how",
+				Version:  "v2",
+				Kind:     "Ducky",
+				Resource: "Duckies",
+			},
+			wantErr: true,
+		},
+		"GVK, version not served by the group": {
+			dh:          NewDuckHunter(mapper, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil),
+			duckVersion: "v1",
+			ref: v1alpha1.ResourceRef{
+				Group:   "teach.me.how",
+				Version: "v3",
+				Kind:    "Ducky",
+			},
+			wantErr: true,
+		},
 		"GVK, unknown ref": {
 			dh:          NewDuckHunter(mapper, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil),
 			duckVersion: "v1",
@@ -655,10 +900,30 @@ func Test_DuckHunter_AddRef(t *testing.T) {
 			} else if got := tc.dh.Ducks(); !reflect.DeepEqual(got, tc.want) {
 				t.Errorf("Ducks() = %v, want %v", got, tc.want)
 			}
+			if got := tc.dh.RefScopeMismatches(); !reflect.DeepEqual(got, tc.wantMismatches) {
+				t.Errorf("RefScopeMismatches() = %v, want %v", got, tc.wantMismatches)
+			}
 		})
 	}
 }
 
+func TestRefScopeMismatch_String(t *testing.T) {
+	m := RefScopeMismatch{
+		DuckVersion: "v1",
+		Ref: v1alpha1.ResourceRef{
+			APIVersion: "teach.me.how/v2",
+			Resource:   "ponds",
+			Scope:      "Namespaced",
+		},
+		Kind:  "Pond",
+		Scope: "Cluster",
+	}
+	want := "v1: Pond teach.me.how/v2 has scope Cluster, not Namespaced"
+	if got := m.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
 func Test_crdToResourceMeta(t *testing.T) {
 	tests := map[string]struct {
 		crd  *apiextensionsv1.CustomResourceDefinition
@@ -684,6 +949,17 @@ func Test_crdToResourceMeta(t *testing.T) {
 				Scope:      "Namespaced",
 			}},
 		},
+		"deprecated storage version": {
+			crd: withDeprecated(withStorage(makeCRD("teach.me.how", "Ducky", map[string]bool{"v2": true}), "v2"), "v2", "going away"),
+			want: []v1alpha1.ResourceMeta{{
+				APIVersion:         "teach.me.how/v2",
+				Kind:               "Ducky",
+				Scope:              "Namespaced",
+				Storage:            true,
+				Deprecated:         true,
+				DeprecationWarning: "going away",
+			}},
+		},
 		"three crd version, only one served": {
 			crd: makeCRD("teach.me.how", "Ducky", map[string]bool{"v1": false, "v2": true, "v3": false}),
 			want: []v1alpha1.ResourceMeta{{
@@ -707,6 +983,70 @@ func Test_crdToResourceMeta(t *testing.T) {
 	}
 }
 
+func Test_DuckHunter_SkippedCRDs(t *testing.T) {
+	notEstablished := makeCRD("teach.me.how", "Ducky", map[string]bool{"v1": true})
+	notEstablished.Name = "duckies.teach.me.how"
+	notEstablished.Status = apiextensionsv1.CustomResourceDefinitionStatus{}
+
+	conflicting := makeCRD("teach.me.how", "Goose", map[string]bool{"v1": true})
+	conflicting.Name = "geese.teach.me.how"
+	conflicting.Status = apiextensionsv1.CustomResourceDefinitionStatus{
+		Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{{
+			Type:    apiextensionsv1.NamesAccepted,
+			Status:  apiextensionsv1.ConditionFalse,
+			Reason:  "KindConflict",
+			Message: `"Goose" is already in use`,
+		}, {
+			Type:    apiextensionsv1.Established,
+			Status:  apiextensionsv1.ConditionFalse,
+			Reason:  "NotAccepted",
+			Message: "not all names are accepted",
+		}},
+	}
+
+	// Renamed CRDs keep serving their accepted names until the new names
+	// are accepted.
+	renamed := makeCRD("teach.me.how", "Swan", map[string]bool{"v1": true})
+	renamed.Name = "swans.teach.me.how"
+	renamed.Spec.Names.Kind = "Cygnet"
+	renamed.Spec.Names.Plural = "cygnets"
+	renamed.Status.Conditions[1] = apiextensionsv1.CustomResourceDefinitionCondition{
+		Type:    apiextensionsv1.NamesAccepted,
+		Status:  apiextensionsv1.ConditionFalse,
+		Reason:  "KindConflict",
+		Message: `"Cygnet" is already in use`,
+	}
+
+	dh := NewDuckHunter(nil, []v1alpha1.DuckVersion{{Name: "v1"}}, nil, nil)
+	dh.AddCRDs([]*apiextensionsv1.CustomResourceDefinition{renamed, notEstablished, conflicting})
+
+	wantDucks := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "teach.me.how/v1",
+			Kind:       "Swan",
+			Resource:   "swans",
+			Scope:      "Namespaced",
+			Preferred:  true,
+		}},
+	}
+	if got := dh.Ducks(); !reflect.DeepEqual(got, wantDucks) {
+		t.Errorf("Ducks() = %v, want %v", got, wantDucks)
+	}
+
+	wantSkipped := []v1alpha1.SkippedCRD{{
+		Name:    "duckies.teach.me.how",
+		Reason:  "NotEstablished",
+		Message: "The CRD is not established yet",
+	}, {
+		Name:    "geese.teach.me.how",
+		Reason:  "NamesNotAccepted",
+		Message: `"Goose" is already in use`,
+	}}
+	if got := dh.SkippedCRDs(); !reflect.DeepEqual(got, wantSkipped) {
+		t.Errorf("SkippedCRDs() = %v, want %v", got, wantSkipped)
+	}
+}
+
 func Test_accessibleGroupResources(t *testing.T) {
 	tests := map[string]struct {
 		cr            *rbacv1.ClusterRole
@@ -818,3 +1158,54 @@ func Test_accessibleGroupResources(t *testing.T) {
 		})
 	}
 }
+
+func Test_setPreferred(t *testing.T) {
+	mapper := NewResourceMapperWithGroups([]*metav1.APIGroup{{
+		Name:             "swan.lake",
+		PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "swan.lake/v1beta1", Version: "v1beta1"},
+	}, {
+		Name:             "teach.me.how",
+		PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "teach.me.how/v3", Version: "v3"},
+	}}, nil)
+
+	tests := map[string]struct {
+		metas []v1alpha1.ResourceMeta
+		want  string
+	}{
+		"preferred version of the group": {
+			metas: []v1alpha1.ResourceMeta{
+				{APIVersion: "swan.lake/v1", Kind: "Ballet"},
+				{APIVersion: "swan.lake/v1beta1", Kind: "Ballet"},
+			},
+			want: "swan.lake/v1beta1",
+		},
+		"preferred version of the group not a duck": {
+			metas: []v1alpha1.ResourceMeta{
+				{APIVersion: "teach.me.how/v1", Kind: "Ducky"},
+				{APIVersion: "teach.me.how/v2", Kind: "Ducky"},
+			},
+			want: "teach.me.how/v2",
+		},
+		"group not discovered, deprecated version": {
+			metas: []v1alpha1.ResourceMeta{
+				{APIVersion: "duck.lake/v1beta1", Kind: "Bread"},
+				{APIVersion: "duck.lake/v2", Kind: "Bread", Deprecated: true},
+			},
+			want: "duck.lake/v1beta1",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			setPreferred(tc.metas, mapper)
+			got := make([]string, 0, 1)
+			for _, meta := range tc.metas {
+				if meta.Preferred {
+					got = append(got, meta.APIVersion)
+				}
+			}
+			if want := []string{tc.want}; !reflect.DeepEqual(got, want) {
+				t.Errorf("preferred = %v, want %v", got, want)
+			}
+		})
+	}
+}
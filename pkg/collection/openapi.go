@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collection
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// maxRefDepth bounds the references followed to resolve a schema.
+const maxRefDepth = 10
+
+// OpenAPIKind is a kind of resource served by the API server, read from the
+// OpenAPI v3 document of its group version.
+type OpenAPIKind struct {
+	schema.GroupVersionKind
+
+	// Resource is the plural resource name of the kind.
+	Resource string
+	// Scope is Namespaced if the resource is listed in a namespace.
+	Scope v1alpha1.ResourceScope
+
+	// schema is the schema of the kind, its references point into components.
+	schema     *apiextensionsv1.JSONSchemaProps
+	components map[string]apiextensionsv1.JSONSchemaProps
+}
+
+// Mismatches returns the paths of the fields of the duck schema the schema of
+// the kind does not satisfy, like SchemaMismatches. Unlike CRDs, the schemas
+// of built-in kinds are complete, so a schema that preserves unknown fields
+// does not satisfy the fields of the duck schema.
+func (k *OpenAPIKind) Mismatches(duck *apiextensionsv1.JSONSchemaProps) []string {
+	if k.schema == nil {
+		return []string{""}
+	}
+	m := &schemaMatcher{resolve: k.resolve, strict: true}
+	return m.appendMismatches(nil, "", duck, k.schema)
+}
+
+// resolve follows the references of a schema into the components of the
+// document. References are either direct or wrapped alone in allOf.
+func (k *OpenAPIKind) resolve(s *apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	for i := 0; s != nil && i < maxRefDepth; i++ {
+		switch {
+		case s.Ref != nil:
+			c, found := k.components[strings.TrimPrefix(*s.Ref, "#/components/schemas/")]
+			if !found {
+				return nil
+			}
+			s = &c
+		case len(s.AllOf) == 1 && s.Type == "" && len(s.Properties) == 0:
+			s = &s.AllOf[0]
+		default:
+			return s
+		}
+	}
+	return s
+}
+
+// openAPIDocument holds the parts of an OpenAPI v3 document of the API server
+// used to find the kinds it serves.
+type openAPIDocument struct {
+	Paths map[string]struct {
+		Get *openAPIOperation `json:"get"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]json.RawMessage `json:"schemas"`
+	} `json:"components"`
+}
+
+// openAPIOperation holds the Kubernetes extensions of an operation.
+type openAPIOperation struct {
+	Action string                   `json:"x-kubernetes-action"`
+	GVK    *schema.GroupVersionKind `json:"x-kubernetes-group-version-kind"`
+}
+
+// openAPISchemaKinds holds the kinds a schema of the components describes.
+type openAPISchemaKinds struct {
+	GVKs []schema.GroupVersionKind `json:"x-kubernetes-group-version-kind"`
+}
+
+// ParseOpenAPIKinds parses an OpenAPI v3 document of the API server and
+// returns the kinds it serves, ordered by kind. Only the kinds that can be
+// listed are returned, leaving out lists, options and subresources.
+func ParseOpenAPIKinds(data []byte) ([]OpenAPIKind, error) {
+	doc := &openAPIDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+
+	listed := make(map[schema.GroupVersionKind]*OpenAPIKind)
+	for path, item := range doc.Paths {
+		op := item.Get
+		if op == nil || op.Action != "list" || op.GVK == nil {
+			continue
+		}
+		k, found := listed[*op.GVK]
+		if !found {
+			k = &OpenAPIKind{
+				GroupVersionKind: *op.GVK,
+				Resource:         path[strings.LastIndex(path, "/")+1:],
+				Scope:            v1alpha1.ClusterScoped,
+			}
+			listed[*op.GVK] = k
+		}
+		if strings.Contains(path, "/namespaces/{namespace}/") {
+			k.Scope = v1alpha1.NamespaceScoped
+		}
+	}
+	if len(listed) == 0 {
+		return nil, nil
+	}
+
+	components := make(map[string]apiextensionsv1.JSONSchemaProps, len(doc.Components.Schemas))
+	for name, raw := range doc.Components.Schemas {
+		s := apiextensionsv1.JSONSchemaProps{}
+		if err := json.Unmarshal(raw, &s); err != nil {
+			// Leave out what cannot be read, references to it are not resolved.
+			continue
+		}
+		components[name] = s
+
+		sk := openAPISchemaKinds{}
+		if err := json.Unmarshal(raw, &sk); err != nil {
+			continue
+		}
+		for _, gvk := range sk.GVKs {
+			if k, found := listed[gvk]; found {
+				s := s
+				k.schema = &s
+			}
+		}
+	}
+
+	kinds := make([]OpenAPIKind, 0, len(listed))
+	for _, k := range listed {
+		k.components = components
+		kinds = append(kinds, *k)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		if kinds[i].Kind != kinds[j].Kind {
+			return kinds[i].Kind < kinds[j].Kind
+		}
+		return kinds[i].GroupVersion().String() < kinds[j].GroupVersion().String()
+	})
+	return kinds, nil
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collection
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// appsV1 is a trimmed OpenAPI v3 document of apps/v1. Deployments refer to
+// their fields directly and StatefulSets through allOf, like older and newer
+// API servers do.
+const appsV1 = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/apis/apps/v1/controllerrevisions": {
+      "get": {"x-kubernetes-action": "list", "x-kubernetes-group-version-kind": {"group": "apps", "kind": "ControllerRevision", "version": "v1"}}
+    },
+    "/apis/apps/v1/namespaces/{namespace}/controllerrevisions": {
+      "get": {"x-kubernetes-action": "list", "x-kubernetes-group-version-kind": {"group": "apps", "kind": "ControllerRevision", "version": "v1"}}
+    },
+    "/apis/apps/v1/namespaces/{namespace}/deployments": {
+      "get": {"x-kubernetes-action": "list", "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"}},
+      "parameters": [{"name": "namespace", "in": "path"}]
+    },
+    "/apis/apps/v1/namespaces/{namespace}/deployments/{name}": {
+      "get": {"x-kubernetes-action": "get", "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"}}
+    },
+    "/apis/apps/v1/namespaces/{namespace}/deployments/{name}/scale": {
+      "get": {"x-kubernetes-action": "get", "x-kubernetes-group-version-kind": {"group": "autoscaling", "kind": "Scale", "version": "v1"}}
+    },
+    "/apis/apps/v1/watch/namespaces/{namespace}/deployments": {
+      "get": {"x-kubernetes-action": "watchlist", "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"}}
+    },
+    "/apis/apps/v1/namespaces/{namespace}/statefulsets": {
+      "get": {"x-kubernetes-action": "list", "x-kubernetes-group-version-kind": {"group": "apps", "kind": "StatefulSet", "version": "v1"}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.ControllerRevision": {
+        "type": "object",
+        "properties": {
+          "data": {"type": "object", "x-kubernetes-preserve-unknown-fields": true},
+          "revision": {"type": "integer", "format": "int64"}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "ControllerRevision", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "properties": {
+          "spec": {"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentList": {
+        "type": "object",
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "DeploymentList", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32"},
+          "template": {"$ref": "#/components/schemas/io.k8s.api.core.v1.PodTemplateSpec"}
+        }
+      },
+      "io.k8s.api.apps.v1.StatefulSet": {
+        "type": "object",
+        "properties": {
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.StatefulSetSpec"}], "default": {}}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "StatefulSet", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.StatefulSetSpec": {
+        "type": "object",
+        "properties": {
+          "template": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.core.v1.PodTemplateSpec"}], "default": {}}
+        }
+      },
+      "io.k8s.api.core.v1.PodTemplateSpec": {
+        "type": "object",
+        "properties": {
+          "spec": {"$ref": "#/components/schemas/io.k8s.api.core.v1.PodSpec"}
+        }
+      },
+      "io.k8s.api.core.v1.PodSpec": {
+        "type": "object",
+        "properties": {
+          "containers": {"type": "array", "items": {"type": "object"}}
+        }
+      }
+    }
+  }
+}`
+
+func podSpecableSchema() *apiextensionsv1.JSONSchemaProps {
+	return &apiextensionsv1.JSONSchemaProps{
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"template": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"containers": {Type: "array"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParseOpenAPIKinds(t *testing.T) {
+	kinds, err := ParseOpenAPIKinds([]byte(appsV1))
+	if err != nil {
+		t.Fatal("ParseOpenAPIKinds() =", err)
+	}
+
+	type listed struct {
+		GVK      schema.GroupVersionKind
+		Resource string
+		Scope    v1alpha1.ResourceScope
+	}
+	got := make([]listed, 0, len(kinds))
+	for _, k := range kinds {
+		got = append(got, listed{GVK: k.GroupVersionKind, Resource: k.Resource, Scope: k.Scope})
+	}
+	want := []listed{{
+		GVK:      schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ControllerRevision"},
+		Resource: "controllerrevisions",
+		Scope:    v1alpha1.NamespaceScoped,
+	}, {
+		GVK:      schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		Resource: "deployments",
+		Scope:    v1alpha1.NamespaceScoped,
+	}, {
+		GVK:      schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+		Resource: "statefulsets",
+		Scope:    v1alpha1.NamespaceScoped,
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("kinds (-want, +got):", diff)
+	}
+}
+
+func TestOpenAPIKind_Mismatches(t *testing.T) {
+	kinds, err := ParseOpenAPIKinds([]byte(appsV1))
+	if err != nil {
+		t.Fatal("ParseOpenAPIKinds() =", err)
+	}
+	byKind := make(map[string]*OpenAPIKind, len(kinds))
+	for i := range kinds {
+		byKind[kinds[i].Kind] = &kinds[i]
+	}
+
+	tests := map[string]struct {
+		kind string
+		duck *apiextensionsv1.JSONSchemaProps
+		want []string
+	}{
+		"direct refs": {
+			kind: "Deployment",
+			duck: podSpecableSchema(),
+		},
+		"refs in allOf": {
+			kind: "StatefulSet",
+			duck: podSpecableSchema(),
+		},
+		"missing field": {
+			kind: "ControllerRevision",
+			duck: podSpecableSchema(),
+			want: []string{"spec"},
+		},
+		"unknown fields do not conform": {
+			kind: "ControllerRevision",
+			duck: &apiextensionsv1.JSONSchemaProps{
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"data": {
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {Type: "object"},
+						},
+					},
+				},
+			},
+			want: []string{"data.spec"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, byKind[tc.kind].Mismatches(tc.duck)); diff != "" {
+				t.Error("Mismatches (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func Test_DuckHunter_AddOpenAPIKinds(t *testing.T) {
+	kinds, err := ParseOpenAPIKinds([]byte(appsV1))
+	if err != nil {
+		t.Fatal("ParseOpenAPIKinds() =", err)
+	}
+
+	dh := NewDuckHunter(nil, []v1alpha1.DuckVersion{{
+		Name:   "v1",
+		Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: podSpecableSchema()},
+	}, {
+		// Without properties every kind would conform, nothing is added.
+		Name:   "v2",
+		Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}},
+	}}, nil, nil)
+	dh.AddOpenAPIKinds(kinds)
+
+	want := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Resource:   "deployments",
+			Scope:      v1alpha1.NamespaceScoped,
+			Preferred:  true,
+			Source:     v1alpha1.OpenAPISource,
+		}, {
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Resource:   "statefulsets",
+			Scope:      v1alpha1.NamespaceScoped,
+			Preferred:  true,
+			Source:     v1alpha1.OpenAPISource,
+		}},
+	}
+	if diff := cmp.Diff(want, dh.Ducks()); diff != "" {
+		t.Error("Ducks (-want, +got):", diff)
+	}
+}
+
+func Test_DuckHunter_AddOpenAPIKinds_afterRef(t *testing.T) {
+	kinds, err := ParseOpenAPIKinds([]byte(appsV1))
+	if err != nil {
+		t.Fatal("ParseOpenAPIKinds() =", err)
+	}
+
+	dh := NewDuckHunter(NewResourceMapper([]*metav1.APIResourceList{{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{{
+			Kind:       "Deployment",
+			Name:       "deployments",
+			Namespaced: true,
+		}},
+	}}), []v1alpha1.DuckVersion{{
+		Name:   "v1",
+		Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: podSpecableSchema()},
+	}}, nil, nil)
+	if err := dh.AddRef("v1", v1alpha1.ResourceRef{
+		Group:   "apps",
+		Version: "v1",
+		Kind:    "Deployment",
+		Scope:   v1alpha1.NamespaceScoped,
+	}); err != nil {
+		t.Fatal("AddRef() =", err)
+	}
+	dh.AddOpenAPIKinds(kinds)
+
+	// The Deployment found by ref is not added again.
+	want := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Resource:   "deployments",
+			Scope:      v1alpha1.NamespaceScoped,
+			Preferred:  true,
+		}, {
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Resource:   "statefulsets",
+			Scope:      v1alpha1.NamespaceScoped,
+			Preferred:  true,
+			Source:     v1alpha1.OpenAPISource,
+		}},
+	}
+	if diff := cmp.Diff(want, dh.Ducks()); diff != "" {
+		t.Error("Ducks (-want, +got):", diff)
+	}
+}
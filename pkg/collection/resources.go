@@ -38,43 +38,124 @@ type ResourceMapper interface {
 	// ResourceFor returns the Kind for the given kind in the given
 	// GroupVersion.
 	ResourceFor(groupVersion, kind string) (string, error)
+	// InfoFor returns what discovery lists about the given resource in the
+	// given GroupVersion.
+	InfoFor(groupVersion, resource string) (ResourceInfo, error)
+	// PreferredVersion returns the version discovery prefers for the given
+	// group, and false if it is not known.
+	PreferredVersion(group string) (string, bool)
 
 	// DeepCopy returns a copy of the ResourceMapper.
 	DeepCopy() ResourceMapper
 }
 
+// ResourceInfo holds what discovery lists about a resource.
+type ResourceInfo struct {
+	// Namespaced indicates whether the resource is namespaced.
+	Namespaced bool
+	// Verbs are the verbs supported on the resource.
+	Verbs []string
+	// ShortNames are the suggested short names of the resource.
+	ShortNames []string
+	// Categories are the groupings the resource belongs to, like `all`.
+	Categories []string
+	// Subresources are the names of the subresources served for the
+	// resource, like `status` and `scale`.
+	Subresources []string
+}
+
+// deepCopy returns a copy of the ResourceInfo.
+func (ri ResourceInfo) deepCopy() ResourceInfo {
+	return ResourceInfo{
+		Namespaced:   ri.Namespaced,
+		Verbs:        copyStrings(ri.Verbs),
+		ShortNames:   copyStrings(ri.ShortNames),
+		Categories:   copyStrings(ri.Categories),
+		Subresources: copyStrings(ri.Subresources),
+	}
+}
+
+// copyStrings returns a copy of the slice, keeping nil as nil.
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
+
 // NewResourceMapper processes a list of APIResourceLists and creates a
 // ResourceMapper that can be used to convert between Resource and Kind or
 // validate a Resource or Kind at a GroupVersion exists on the cluster.
 func NewResourceMapper(apiGroups []*metav1.APIResourceList) ResourceMapper {
+	return NewResourceMapperWithGroups(nil, apiGroups)
+}
+
+// NewResourceMapperWithGroups is NewResourceMapper, also knowing the
+// preferred version of the groups.
+func NewResourceMapperWithGroups(groups []*metav1.APIGroup, apiGroups []*metav1.APIResourceList) ResourceMapper {
+	var preferred map[string]string
+	for _, group := range groups {
+		if group == nil || group.PreferredVersion.Version == "" {
+			continue
+		}
+		if preferred == nil {
+			preferred = make(map[string]string, len(groups))
+		}
+		preferred[group.Name] = group.PreferredVersion.Version
+	}
+
 	mappings := make(map[string]mapping)
 
 	for _, apiGroup := range apiGroups {
-		mappings[apiGroup.GroupVersion] = mapping{
-			r2k: make(map[string]string),
-			k2r: make(map[string]string),
+		m := mapping{
+			r2k:  make(map[string]string),
+			k2r:  make(map[string]string),
+			info: make(map[string]ResourceInfo),
 		}
+		mappings[apiGroup.GroupVersion] = m
 
 		for _, apiResource := range apiGroup.APIResources {
-			// the API groups contains all the endpoints, we don't want the
-			//sub mappings for now. We will skip.
+			// Subresources are kept on their resource below.
 			if strings.Contains(apiResource.Name, "/") {
 				continue
 			}
-			mappings[apiGroup.GroupVersion].k2r[apiResource.Kind] = apiResource.Name
-			mappings[apiGroup.GroupVersion].r2k[apiResource.Name] = apiResource.Kind
+			m.k2r[apiResource.Kind] = apiResource.Name
+			m.r2k[apiResource.Name] = apiResource.Kind
+			m.info[apiResource.Name] = ResourceInfo{
+				Namespaced: apiResource.Namespaced,
+				Verbs:      copyStrings(apiResource.Verbs),
+				ShortNames: copyStrings(apiResource.ShortNames),
+				Categories: copyStrings(apiResource.Categories),
+			}
+		}
+
+		// The API groups list the subresources as `<resource>/<subresource>`.
+		for _, apiResource := range apiGroup.APIResources {
+			parts := strings.SplitN(apiResource.Name, "/", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			if info, found := m.info[parts[0]]; found {
+				info.Subresources = append(info.Subresources, parts[1])
+				m.info[parts[0]] = info
+			}
 		}
 	}
-	return &resourceMapper{mappings: mappings}
+	return &resourceMapper{mappings: mappings, preferred: preferred}
 }
 
 type resourceMapper struct {
 	mappings map[string]mapping
+	// preferred maps the groups to their preferred version.
+	preferred map[string]string
 }
 
 type mapping struct {
-	r2k map[string]string
-	k2r map[string]string
+	r2k  map[string]string
+	k2r  map[string]string
+	info map[string]ResourceInfo
 }
 
 // KindExists implements ResourceMapper.KindExists
@@ -124,13 +205,33 @@ func (rm *resourceMapper) ResourceFor(groupVersion, kind string) (string, error)
 
 }
 
+// InfoFor implements ResourceMapper.InfoFor
+func (rm *resourceMapper) InfoFor(groupVersion, resource string) (ResourceInfo, error) {
+	m, found := rm.mappings[groupVersion]
+	if !found {
+		return ResourceInfo{}, fmt.Errorf("resource %s not found in %s", resource, groupVersion)
+	}
+	info, found := m.info[resource]
+	if !found {
+		return ResourceInfo{}, fmt.Errorf("resource %s not found in %s", resource, groupVersion)
+	}
+	return info.deepCopy(), nil
+}
+
+// PreferredVersion implements ResourceMapper.PreferredVersion
+func (rm *resourceMapper) PreferredVersion(group string) (string, bool) {
+	version, found := rm.preferred[group]
+	return version, found
+}
+
 // DeepCopy implements ResourceMapper.DeepCopy
 func (rm *resourceMapper) DeepCopy() ResourceMapper {
 	mappings := make(map[string]mapping, len(rm.mappings))
 	for mk, mv := range rm.mappings {
 		mappings[mk] = mapping{
-			r2k: make(map[string]string, len(mv.r2k)),
-			k2r: make(map[string]string, len(mv.k2r)),
+			r2k:  make(map[string]string, len(mv.r2k)),
+			k2r:  make(map[string]string, len(mv.k2r)),
+			info: make(map[string]ResourceInfo, len(mv.info)),
 		}
 		for k, v := range mv.r2k {
 			mappings[mk].r2k[k] = v
@@ -138,6 +239,16 @@ func (rm *resourceMapper) DeepCopy() ResourceMapper {
 		for k, v := range mv.k2r {
 			mappings[mk].k2r[k] = v
 		}
+		for k, v := range mv.info {
+			mappings[mk].info[k] = v.deepCopy()
+		}
+	}
+	var preferred map[string]string
+	if rm.preferred != nil {
+		preferred = make(map[string]string, len(rm.preferred))
+		for k, v := range rm.preferred {
+			preferred[k] = v
+		}
 	}
-	return &resourceMapper{mappings: mappings}
+	return &resourceMapper{mappings: mappings, preferred: preferred}
 }
@@ -41,8 +41,9 @@ func TestNewResourceMapper(t *testing.T) {
 			want: &resourceMapper{
 				mappings: map[string]mapping{
 					"swan.lake/v1": {
-						r2k: map[string]string{"ballets": "Ballet"},
-						k2r: map[string]string{"Ballet": "ballets"},
+						r2k:  map[string]string{"ballets": "Ballet"},
+						k2r:  map[string]string{"Ballet": "ballets"},
+						info: map[string]ResourceInfo{"ballets": {}},
 					},
 				},
 			},
@@ -66,6 +67,9 @@ func TestNewResourceMapper(t *testing.T) {
 					"swan.lake/v1": {
 						r2k: map[string]string{"ballets": "Ballet"},
 						k2r: map[string]string{"Ballet": "ballets"},
+						info: map[string]ResourceInfo{
+							"ballets": {Subresources: []string{"status"}},
+						},
 					},
 				},
 			},
@@ -85,8 +89,9 @@ func TestNewResourceMapper(t *testing.T) {
 			want: &resourceMapper{
 				mappings: map[string]mapping{
 					"swan.lake/v1": {
-						r2k: map[string]string{"ballets": "Ballet", "shoes": "Shoe"},
-						k2r: map[string]string{"Ballet": "ballets", "Shoe": "shoes"},
+						r2k:  map[string]string{"ballets": "Ballet", "shoes": "Shoe"},
+						k2r:  map[string]string{"Ballet": "ballets", "Shoe": "shoes"},
+						info: map[string]ResourceInfo{"ballets": {}, "shoes": {}},
 					},
 				},
 			},
@@ -112,12 +117,61 @@ func TestNewResourceMapper(t *testing.T) {
 			want: &resourceMapper{
 				mappings: map[string]mapping{
 					"duck.lake/v2": {
-						r2k: map[string]string{"breads": "Bread"},
-						k2r: map[string]string{"Bread": "breads"},
+						r2k:  map[string]string{"breads": "Bread"},
+						k2r:  map[string]string{"Bread": "breads"},
+						info: map[string]ResourceInfo{"breads": {}},
+					},
+					"swan.lake/v1": {
+						r2k:  map[string]string{"ballets": "Ballet", "shoes": "Shoe"},
+						k2r:  map[string]string{"Ballet": "ballets", "Shoe": "shoes"},
+						info: map[string]ResourceInfo{"ballets": {}, "shoes": {}},
 					},
+				},
+			},
+		},
+		"one api, one resource with capabilities": {
+			apis: []*metav1.APIResourceList{
+				{
+					GroupVersion: "swan.lake/v1",
+					APIResources: []metav1.APIResource{{
+						Name:       "ballets/scale",
+						Namespaced: true,
+						Kind:       "Scale",
+						Group:      "autoscaling",
+						Version:    "v1",
+						Verbs:      []string{"get", "patch", "update"},
+					}, {
+						Name:       "ballets",
+						Namespaced: true,
+						Kind:       "Ballet",
+						Verbs:      []string{"get", "list", "watch"},
+						ShortNames: []string{"bal"},
+						Categories: []string{"all"},
+					}, {
+						Name:       "ballets/status",
+						Namespaced: true,
+						Kind:       "Ballet",
+						Verbs:      []string{"get", "patch", "update"},
+					}, {
+						Name:       "shoes/status",
+						Namespaced: true,
+						Kind:       "Shoe",
+					}},
+				}},
+			want: &resourceMapper{
+				mappings: map[string]mapping{
 					"swan.lake/v1": {
-						r2k: map[string]string{"ballets": "Ballet", "shoes": "Shoe"},
-						k2r: map[string]string{"Ballet": "ballets", "Shoe": "shoes"},
+						r2k: map[string]string{"ballets": "Ballet"},
+						k2r: map[string]string{"Ballet": "ballets"},
+						info: map[string]ResourceInfo{
+							"ballets": {
+								Namespaced:   true,
+								Verbs:        []string{"get", "list", "watch"},
+								ShortNames:   []string{"bal"},
+								Categories:   []string{"all"},
+								Subresources: []string{"scale", "status"},
+							},
+						},
 					},
 				},
 			},
@@ -189,6 +243,68 @@ func TestResourceFor(t *testing.T) {
 	}
 }
 
+func TestInfoFor(t *testing.T) {
+	rm := NewResourceMapper([]*metav1.APIResourceList{{
+		GroupVersion: "duck.lake/v2",
+		APIResources: []metav1.APIResource{{
+			Name:       "breads",
+			Namespaced: true,
+			Kind:       "Bread",
+			Verbs:      []string{"get", "list"},
+		}, {
+			Name: "breads/scale",
+			Kind: "Scale",
+		}},
+	}})
+
+	tests := map[string]struct {
+		groupVersion string
+		resource     string
+		want         ResourceInfo
+		wantErr      bool
+	}{
+		"duck lake breads": {
+			groupVersion: "duck.lake/v2",
+			resource:     "breads",
+			want: ResourceInfo{
+				Namespaced:   true,
+				Verbs:        []string{"get", "list"},
+				Subresources: []string{"scale"},
+			},
+		},
+		"subresource breads/scale->error": {
+			groupVersion: "duck.lake/v2",
+			resource:     "breads/scale",
+			wantErr:      true,
+		},
+		"unknown breads->error": {
+			groupVersion: "data.lake/v3",
+			resource:     "breads",
+			wantErr:      true,
+		}}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := rm.InfoFor(tc.groupVersion, tc.resource)
+			if err != nil {
+				if !tc.wantErr {
+					t.Errorf("expected error calling rm.InfoFor(%q, %q): %v", tc.groupVersion, tc.resource, err)
+				}
+			} else if tc.wantErr {
+				t.Errorf("rm.InfoFor(%q, %q) = %v, want an error", tc.groupVersion, tc.resource, got)
+			} else if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rm.InfoFor(%q, %q) = %v, want %v", tc.groupVersion, tc.resource, got, tc.want)
+			}
+		})
+	}
+
+	// The info returned is a copy.
+	info, _ := rm.InfoFor("duck.lake/v2", "breads")
+	info.Verbs[0] = "delete"
+	if got, _ := rm.DeepCopy().InfoFor("duck.lake/v2", "breads"); got.Verbs[0] != "get" {
+		t.Errorf("rm.InfoFor() verbs = %v, want a copy", got.Verbs)
+	}
+}
+
 func TestKindExists(t *testing.T) {
 	rm := &resourceMapper{
 		mappings: map[string]mapping{
@@ -350,3 +466,17 @@ func TestResourceExists(t *testing.T) {
 		})
 	}
 }
+
+func TestPreferredVersion(t *testing.T) {
+	rm := NewResourceMapperWithGroups([]*metav1.APIGroup{{
+		Name:             "swan.lake",
+		PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "swan.lake/v1", Version: "v1"},
+	}}, nil).DeepCopy()
+
+	if got, found := rm.PreferredVersion("swan.lake"); !found || got != "v1" {
+		t.Errorf("PreferredVersion(swan.lake) = %q, %t, want v1", got, found)
+	}
+	if got, found := rm.PreferredVersion("duck.lake"); found {
+		t.Errorf("PreferredVersion(duck.lake) = %q, want not found", got)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collection
+
+import (
+	"sort"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// SchemaMismatches compares the partial schema of a duck version with the
+// schema of a resource and returns the paths of the duck fields the resource
+// schema does not satisfy. A field is satisfied if the resource schema holds
+// the field with the same type, or if the resource schema preserves unknown
+// fields at that level. No mismatches are reported if either schema is nil.
+func SchemaMismatches(duck, resource *apiextensionsv1.JSONSchemaProps) []string {
+	return (&schemaMatcher{}).appendMismatches(nil, "", duck, resource)
+}
+
+// schemaMatcher compares the schema of a resource with a duck schema.
+type schemaMatcher struct {
+	// resolve follows the references of the resource schema. Optional.
+	resolve func(*apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps
+	// strict does not let a resource schema that preserves unknown fields
+	// satisfy the fields of the duck schema.
+	strict bool
+}
+
+func (m *schemaMatcher) appendMismatches(mismatches []string, path string, duck, resource *apiextensionsv1.JSONSchemaProps) []string {
+	if m.resolve != nil {
+		resource = m.resolve(resource)
+	}
+	if duck == nil || resource == nil {
+		return mismatches
+	}
+
+	if duck.Type != "" && resource.Type != "" && duck.Type != resource.Type {
+		return append(mismatches, path)
+	}
+
+	// Walk the properties in a stable order so the reported paths are stable.
+	names := make([]string, 0, len(duck.Properties))
+	for name := range duck.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dp := duck.Properties[name]
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		if rp, found := resource.Properties[name]; found {
+			mismatches = m.appendMismatches(mismatches, fieldPath, &dp, &rp)
+		} else if resource.AdditionalProperties != nil && resource.AdditionalProperties.Schema != nil {
+			mismatches = m.appendMismatches(mismatches, fieldPath, &dp, resource.AdditionalProperties.Schema)
+		} else if m.strict || !preservesUnknownFields(resource) {
+			mismatches = append(mismatches, fieldPath)
+		}
+	}
+
+	if duck.Items != nil && duck.Items.Schema != nil && resource.Items != nil && resource.Items.Schema != nil {
+		mismatches = m.appendMismatches(mismatches, path+"[]", duck.Items.Schema, resource.Items.Schema)
+	}
+	return mismatches
+}
+
+// preservesUnknownFields returns true if the schema accepts fields it does not
+// list in its properties.
+func preservesUnknownFields(schema *apiextensionsv1.JSONSchemaProps) bool {
+	if schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields {
+		return true
+	}
+	return schema.AdditionalProperties != nil && schema.AdditionalProperties.Allows
+}
+
+// crdVersionSchema returns the openAPIV3Schema of the given version of the
+// CRD, or nil if there is none.
+func crdVersionSchema(crd *apiextensionsv1.CustomResourceDefinition, version string) *apiextensionsv1.JSONSchemaProps {
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version && v.Schema != nil {
+			return v.Schema.OpenAPIV3Schema
+		}
+	}
+	return nil
+}
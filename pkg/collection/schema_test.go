@@ -0,0 +1,233 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collection
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"knative.dev/pkg/ptr"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func addressableSchema() *apiextensionsv1.JSONSchemaProps {
+	return &apiextensionsv1.JSONSchemaProps{
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"status": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"address": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"url": {Type: "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSchemaMismatches(t *testing.T) {
+	tests := map[string]struct {
+		duck     *apiextensionsv1.JSONSchemaProps
+		resource *apiextensionsv1.JSONSchemaProps
+		want     []string
+	}{
+		"no duck schema": {
+			resource: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+		},
+		"no resource schema": {
+			duck: addressableSchema(),
+		},
+		"conforming": {
+			duck: addressableSchema(),
+			resource: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"spec": {Type: "object"},
+					"status": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"address": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"url":  {Type: "string"},
+									"name": {Type: "string"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"missing field": {
+			duck: addressableSchema(),
+			resource: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"status": {
+						Type:       "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{},
+					},
+				},
+			},
+			want: []string{"status.address"},
+		},
+		"wrong type": {
+			duck: addressableSchema(),
+			resource: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"status": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"address": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"url": {Type: "integer"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"status.address.url"},
+		},
+		"preserves unknown fields": {
+			duck: addressableSchema(),
+			resource: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"status": {
+						Type:                   "object",
+						XPreserveUnknownFields: ptr.Bool(true),
+					},
+				},
+			},
+		},
+		"additional properties": {
+			duck: addressableSchema(),
+			resource: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"status": {
+						Type: "object",
+						AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+							Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+						},
+					},
+				},
+			},
+			want: []string{"status.address"},
+		},
+		"array items": {
+			duck: &apiextensionsv1.JSONSchemaProps{
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"conditions": {
+						Type: "array",
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+							Schema: &apiextensionsv1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"type":   {Type: "string"},
+									"status": {Type: "string"},
+								},
+							},
+						},
+					},
+				},
+			},
+			resource: &apiextensionsv1.JSONSchemaProps{
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"conditions": {
+						Type: "array",
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+							Schema: &apiextensionsv1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"type": {Type: "string"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"conditions[].status"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := SchemaMismatches(tc.duck, tc.resource)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("SchemaMismatches (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func Test_DuckHunter_NonConformingDucks(t *testing.T) {
+	conforming := makeCRD("teach.me.how", "Ducky", map[string]bool{"v1": true})
+	conforming.Spec.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: addressableSchema(),
+	}
+	nonConforming := makeCRD("teach.me.how", "Goose", map[string]bool{"v1": true})
+	nonConforming.Spec.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"status": {Type: "object"},
+			},
+		},
+	}
+
+	dh := NewDuckHunter(nil, []v1alpha1.DuckVersion{{
+		Name:   "v1",
+		Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: addressableSchema()},
+	}}, nil, nil)
+	dh.AddCRDs([]*apiextensionsv1.CustomResourceDefinition{conforming, nonConforming})
+
+	wantDucks := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "teach.me.how/v1",
+			Kind:       "Ducky",
+			Resource:   "duckies",
+			Scope:      "Namespaced",
+			Preferred:  true,
+		}},
+	}
+	if diff := cmp.Diff(wantDucks, dh.Ducks()); diff != "" {
+		t.Error("Ducks (-want, +got) =", diff)
+	}
+
+	wantNonConforming := map[string][]v1alpha1.NonConformingResourceMeta{
+		"v1": {{
+			ResourceMeta: v1alpha1.ResourceMeta{
+				APIVersion: "teach.me.how/v1",
+				Kind:       "Goose",
+				Resource:   "gooses",
+				Scope:      "Namespaced",
+			},
+			MismatchedFields: []string{"status.address"},
+		}},
+	}
+	if diff := cmp.Diff(wantNonConforming, dh.NonConformingDucks()); diff != "" {
+		t.Error("NonConformingDucks (-want, +got) =", diff)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	"knative.dev/discovery/pkg/client/clientset/versioned"
+)
+
+// now is used to compute the age of objects, replaced in tests.
+var now = time.Now
+
+// Params holds the clients used by the commands. Clients that are not set
+// are created from the kubeconfig flags of the root command.
+type Params struct {
+	// Client is the client of the discovery API.
+	Client versioned.Interface
+	// Dynamic is used to list the instances of the ducks.
+	Dynamic dynamic.Interface
+	// Namespace is the namespace of the current kubeconfig context.
+	Namespace string
+
+	kubeconfig string
+	context    string
+}
+
+// New creates the kubectl duck cli command set.
+func New(p *Params) *cobra.Command {
+	if p == nil {
+		p = &Params{}
+	}
+
+	var cmd = &cobra.Command{
+		Use:   "kubectl-duck",
+		Short: "Explore the duck types installed in a cluster.",
+		Long: `Explore the duck types installed in a cluster.
+
+Duck types are found with ClusterDuckTypes, for example to find what can be
+used as a sink:
+
+  kubectl duck get addressables`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return p.init()
+		},
+	}
+	cmd.PersistentFlags().StringVar(&p.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use.")
+	cmd.PersistentFlags().StringVar(&p.context, "context", "", "The name of the kubeconfig context to use.")
+
+	addListCmd(cmd, p)
+	addDescribeCmd(cmd, p)
+	addGetCmd(cmd, p)
+	addWhichCmd(cmd, p)
+
+	return cmd
+}
+
+// init creates the clients that are not set yet.
+func (p *Params) init() error {
+	if p.Client != nil && p.Dynamic != nil {
+		return nil
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = p.kubeconfig
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{CurrentContext: p.context})
+
+	rest, err := config.ClientConfig()
+	if err != nil {
+		return err
+	}
+	if p.Namespace, _, err = config.Namespace(); err != nil {
+		return err
+	}
+	if p.Client == nil {
+		if p.Client, err = versioned.NewForConfig(rest); err != nil {
+			return err
+		}
+	}
+	if p.Dynamic == nil {
+		if p.Dynamic, err = dynamic.NewForConfig(rest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findDuckType finds a ClusterDuckType by its name, or by the name, plural or
+// singular of the duck type it defines.
+func findDuckType(ctx context.Context, client versioned.Interface, name string) (*v1alpha1.ClusterDuckType, error) {
+	list, err := client.DiscoveryV1alpha1().ClusterDuckTypes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *v1alpha1.ClusterDuckType
+	for i, dt := range list.Items {
+		if dt.Name == name {
+			return &list.Items[i], nil
+		}
+		names := dt.Spec.Names
+		if !strings.EqualFold(names.Name, name) && names.Plural != name && names.Singular != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("duck type %q is ambiguous, it matches %s and %s", name, found.Name, dt.Name)
+		}
+		found = &list.Items[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("duck type %q not found", name)
+	}
+	return found, nil
+}
+
+// latestVersion returns the latest version of the duck type, comparing the
+// version names like Kubernetes versions.
+func latestVersion(dt *v1alpha1.ClusterDuckType) string {
+	latest := ""
+	for _, dv := range dt.Spec.Versions {
+		if latest == "" || version.CompareKubeAwareVersionStrings(dv.Name, latest) > 0 {
+			latest = dv.Name
+		}
+	}
+	return latest
+}
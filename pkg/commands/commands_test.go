@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"knative.dev/pkg/apis"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	fakeclientset "knative.dev/discovery/pkg/client/clientset/versioned/fake"
+)
+
+func addressables() *v1alpha1.ClusterDuckType {
+	dt := &v1alpha1.ClusterDuckType{
+		ObjectMeta: metav1.ObjectMeta{Name: "addressables.duck.knative.dev"},
+		Spec: v1alpha1.ClusterDuckTypeSpec{
+			Group: "duck.knative.dev",
+			Names: v1alpha1.DuckTypeNames{
+				Name:     "Addressable",
+				Plural:   "addressables",
+				Singular: "addressable",
+			},
+			Versions: []v1alpha1.DuckVersion{{
+				Name: "v1",
+				AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{{
+					Name:     "URL",
+					Type:     "string",
+					JSONPath: ".status.address.url",
+				}},
+			}},
+			Selectors: []v1alpha1.CustomResourceDefinitionSelector{{
+				LabelSelector: "duck.knative.dev/addressable=true",
+			}},
+		},
+		Status: v1alpha1.ClusterDuckTypeStatus{
+			Ducks: map[string][]v1alpha1.ResourceMeta{
+				"v1": {{
+					APIVersion: "messaging.knative.dev/v1",
+					Kind:       "Channel",
+					Resource:   "channels",
+					Scope:      v1alpha1.NamespaceScoped,
+				}, {
+					APIVersion: "serving.knative.dev/v1",
+					Kind:       "Service",
+					Resource:   "services",
+					Scope:      v1alpha1.NamespaceScoped,
+				}},
+			},
+			DuckCount: 2,
+		},
+	}
+	dt.Status.SetConditions(apis.Conditions{{
+		Type:   apis.ConditionReady,
+		Status: "True",
+	}})
+	return dt
+}
+
+func podspecables() *v1alpha1.ClusterDuckType {
+	return &v1alpha1.ClusterDuckType{
+		ObjectMeta: metav1.ObjectMeta{Name: "podspecables.duck.knative.dev"},
+		Spec: v1alpha1.ClusterDuckTypeSpec{
+			Group: "duck.knative.dev",
+			Names: v1alpha1.DuckTypeNames{
+				Name:     "PodSpecable",
+				Plural:   "podspecables",
+				Singular: "podspecable",
+			},
+			Versions: []v1alpha1.DuckVersion{{Name: "v1"}, {Name: "v1alpha1"}},
+		},
+		Status: v1alpha1.ClusterDuckTypeStatus{
+			Ducks: map[string][]v1alpha1.ResourceMeta{
+				"v1": {{
+					APIVersion: "serving.knative.dev/v1",
+					Kind:       "Service",
+					Resource:   "services",
+					Scope:      v1alpha1.NamespaceScoped,
+				}, {
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				}},
+			},
+			DuckCount: 1,
+		},
+	}
+}
+
+func instance(apiVersion, kind, namespace, name, url string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	if url != "" {
+		_ = unstructured.SetNestedField(u.Object, url, "status", "address", "url")
+	}
+	return u
+}
+
+func run(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+
+	dynamic := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "serving.knative.dev", Version: "v1", Resource: "services"}:   "ServiceList",
+			{Group: "messaging.knative.dev", Version: "v1", Resource: "channels"}: "ChannelList",
+		},
+		instance("serving.knative.dev/v1", "Service", "default", "hello", "http://hello.default.example.com"),
+		instance("messaging.knative.dev/v1", "Channel", "default", "events", ""),
+		instance("messaging.knative.dev/v1", "Channel", "other", "news", "http://news.other.svc"),
+	)
+
+	cmd := New(&Params{
+		Client:    fakeclientset.NewSimpleClientset(addressables(), podspecables()),
+		Dynamic:   dynamic,
+		Namespace: "default",
+	})
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func lines(l ...string) string {
+	return strings.Join(l, "\n") + "\n"
+}
+
+func TestList(t *testing.T) {
+	out, _, err := run(t, "list")
+	if err != nil {
+		t.Fatal("list failed:", err)
+	}
+	want := lines(
+		"NAME                            DUCK          VERSIONS      DUCKS   READY     AGE",
+		"addressables.duck.knative.dev   Addressable   v1            2       True      <unknown>",
+		"podspecables.duck.knative.dev   PodSpecable   v1,v1alpha1   1       Unknown   <unknown>",
+	)
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Error("list (-want, +got):", diff)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	out, _, err := run(t, "describe", "Addressable")
+	if err != nil {
+		t.Fatal("describe failed:", err)
+	}
+	for _, want := range []string{
+		"Name:       addressables.duck.knative.dev\n",
+		"Selectors:  duck.knative.dev/addressable=true\n",
+		"Role:       <none>\n",
+		"    Service  serving.knative.dev/v1    services  Namespaced  false\n",
+		"  Ready  True    <none>  <none>\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("describe does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribe_NotFound(t *testing.T) {
+	if _, _, err := run(t, "describe", "sinks"); err == nil || err.Error() != `duck type "sinks" not found` {
+		t.Error("describe unexpected error:", err)
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{{
+		name: "current namespace",
+		args: []string{"get", "addressables"},
+		want: lines(
+			"NAME     KIND                            URL",
+			"events   Channel.messaging.knative.dev   <none>",
+			"hello    Service.serving.knative.dev     http://hello.default.example.com",
+		),
+	}, {
+		name: "namespace",
+		args: []string{"get", "addressables", "-n", "other"},
+		want: lines(
+			"NAME   KIND                            URL",
+			"news   Channel.messaging.knative.dev   http://news.other.svc",
+		),
+	}, {
+		name: "all namespaces",
+		args: []string{"get", "addressables.duck.knative.dev", "-A"},
+		want: lines(
+			"NAMESPACE   NAME     KIND                            URL",
+			"default     events   Channel.messaging.knative.dev   <none>",
+			"other       news     Channel.messaging.knative.dev   http://news.other.svc",
+			"default     hello    Service.serving.knative.dev     http://hello.default.example.com",
+		),
+	}, {
+		name:    "nothing found",
+		args:    []string{"get", "addressables", "-n", "empty"},
+		wantErr: "No resources found in empty namespace.\n",
+	}, {
+		name: "some kinds cannot be listed",
+		args: []string{"get", "podspecables"},
+		want: lines(
+			"NAME    KIND                          AGE",
+			"hello   Service.serving.knative.dev   <none>",
+		),
+		wantErr: "resource of Deployment apps/v1 is not known",
+	}, {
+		name:    "unknown version",
+		args:    []string{"get", "addressables", "--version", "v2"},
+		wantErr: `version "v2" not found in addressables.duck.knative.dev`,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, errOut, err := run(t, tc.args...)
+			if err != nil {
+				errOut = err.Error()
+			}
+			if diff := cmp.Diff(tc.want, out); diff != "" {
+				t.Error("get (-want, +got):", diff)
+			}
+			if diff := cmp.Diff(tc.wantErr, errOut); diff != "" {
+				t.Error("get error (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestWhich(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    string
+		wantErr string
+	}{{
+		name: "kind",
+		kind: "Service",
+		want: lines(
+			"DUCK TYPE                       DUCK          VERSION   KIND      API VERSION",
+			"addressables.duck.knative.dev   Addressable   v1        Service   serving.knative.dev/v1",
+			"podspecables.duck.knative.dev   PodSpecable   v1        Service   serving.knative.dev/v1",
+		),
+	}, {
+		name: "resource and group",
+		kind: "channels.messaging.knative.dev",
+		want: lines(
+			"DUCK TYPE                       DUCK          VERSION   KIND      API VERSION",
+			"addressables.duck.knative.dev   Addressable   v1        Channel   messaging.knative.dev/v1",
+		),
+	}, {
+		name:    "other group",
+		kind:    "Service.core",
+		wantErr: "No duck types found for Service.core.\n",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, errOut, err := run(t, "which", tc.kind)
+			if err != nil {
+				t.Fatal("which failed:", err)
+			}
+			if diff := cmp.Diff(tc.want, out); diff != "" {
+				t.Error("which (-want, +got):", diff)
+			}
+			if diff := cmp.Diff(tc.wantErr, errOut); diff != "" {
+				t.Error("which error (-want, +got):", diff)
+			}
+		})
+	}
+}
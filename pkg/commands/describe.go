@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func addDescribeCmd(root *cobra.Command, p *Params) {
+	var cmd = &cobra.Command{
+		Use:   "describe <ducktype>",
+		Short: "Show the details of a duck type and the ducks implementing it.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dt, err := findDuckType(cmd.Context(), p.Client, args[0])
+			if err != nil {
+				return err
+			}
+			return describe(cmd.OutOrStdout(), dt)
+		},
+	}
+
+	root.AddCommand(cmd)
+}
+
+// describe prints the spec and status of the ClusterDuckType.
+func describe(out io.Writer, dt *v1alpha1.ClusterDuckType) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", dt.Name)
+	fmt.Fprintf(w, "Duck:\t%s\n", dt.Spec.Names.Name)
+	fmt.Fprintf(w, "Group:\t%s\n", dt.Spec.Group)
+	fmt.Fprintf(w, "Names:\t%s, %s\n", dt.Spec.Names.Plural, dt.Spec.Names.Singular)
+
+	selectors := make([]string, 0, len(dt.Spec.Selectors))
+	for _, s := range dt.Spec.Selectors {
+		selectors = append(selectors, s.LabelSelector)
+	}
+	fmt.Fprintf(w, "Selectors:\t%s\n", orNone(strings.Join(selectors, "; ")))
+
+	role := ""
+	if dt.Spec.Role != nil && dt.Spec.Role.RoleRef != nil {
+		role = dt.Spec.Role.RoleRef.Kind + "/" + dt.Spec.Role.RoleRef.Name
+	}
+	fmt.Fprintf(w, "Role:\t%s\n", orNone(role))
+
+	fmt.Fprintln(w, "Versions:")
+	for _, dv := range dt.Spec.Versions {
+		fmt.Fprintf(w, "  %s:\n", dv.Name)
+		ducks := dt.Status.Ducks[dv.Name]
+		if len(ducks) == 0 {
+			fmt.Fprintln(w, "    <none>")
+			continue
+		}
+		fmt.Fprintln(w, "    KIND\tAPI VERSION\tRESOURCE\tSCOPE\tACCESSIBLE")
+		for _, meta := range ducks {
+			fmt.Fprintf(w, "    %s\t%s\t%s\t%s\t%t\n", meta.Kind, meta.APIVersion, orNone(meta.Resource), meta.Scope, meta.AccessibleViaClusterRole)
+		}
+	}
+
+	if len(dt.Status.UnresolvedRefs) > 0 {
+		fmt.Fprintln(w, "Unresolved Refs:")
+		versions := make([]string, 0, len(dt.Status.UnresolvedRefs))
+		for version := range dt.Status.UnresolvedRefs {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+		for _, version := range versions {
+			for _, ref := range dt.Status.UnresolvedRefs[version] {
+				fmt.Fprintf(w, "  %s:\t%s\n", version, ref.Error)
+			}
+		}
+	}
+
+	if len(dt.Status.NonConformingDucks) > 0 {
+		fmt.Fprintln(w, "Non Conforming Ducks:")
+		versions := make([]string, 0, len(dt.Status.NonConformingDucks))
+		for version := range dt.Status.NonConformingDucks {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+		for _, version := range versions {
+			for _, meta := range dt.Status.NonConformingDucks[version] {
+				fmt.Fprintf(w, "  %s:\t%s %s\t%s\n", version, meta.Kind, meta.APIVersion, strings.Join(meta.MismatchedFields, ", "))
+			}
+		}
+	}
+
+	if len(dt.Status.SkippedCRDs) > 0 {
+		fmt.Fprintln(w, "Skipped CRDs:")
+		for _, sc := range dt.Status.SkippedCRDs {
+			fmt.Fprintf(w, "  %s:\t%s\t%s\n", sc.Name, sc.Reason, orNone(sc.Message))
+		}
+	}
+
+	if len(dt.Status.Instances) > 0 {
+		fmt.Fprintln(w, "Instances:")
+		fmt.Fprintln(w, "  KIND\tAPI VERSION\tCOUNT")
+		for _, ic := range dt.Status.Instances {
+			fmt.Fprintf(w, "  %s\t%s\t%d\n", ic.Kind, ic.APIVersion, ic.Count)
+		}
+	}
+
+	if r := dt.Status.Readiness; r != nil {
+		fmt.Fprintf(w, "Readiness:\t%d ready, %d not ready, %d unknown\n", r.Ready, r.NotReady, r.Unknown)
+		for _, nri := range r.WorstOffenders {
+			name := nri.Name
+			if nri.Namespace != "" {
+				name = nri.Namespace + "/" + name
+			}
+			fmt.Fprintf(w, "  %s %s\t%s\t%s\n", nri.Kind, name, nri.Status, orNone(nri.Reason))
+		}
+	}
+
+	fmt.Fprintln(w, "Conditions:")
+	if len(dt.Status.Conditions) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, cond := range dt.Status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", cond.Type, cond.Status, orNone(cond.Reason), orNone(cond.Message))
+		}
+	}
+
+	return w.Flush()
+}
+
+// orNone returns s, or <none> if s is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"knative.dev/discovery/pkg/table"
+)
+
+func addGetCmd(root *cobra.Command, p *Params) {
+	var namespace, version, output string
+	var allNamespaces bool
+
+	var cmd = &cobra.Command{
+		Use:   "get <ducktype>",
+		Short: "List the instances of the ducks implementing a duck type.",
+		Long: `List the instances of the ducks implementing a duck type, with the printer
+columns of the duck type version. For example, to list what can be used as a
+sink in the current namespace:
+
+  kubectl duck get addressables`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Validation
+			if output != "" && output != "wide" {
+				return fmt.Errorf("unknown output format %q, expected wide", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dt, err := findDuckType(cmd.Context(), p.Client, args[0])
+			if err != nil {
+				return err
+			}
+			if version == "" {
+				version = latestVersion(dt)
+			}
+
+			ns := namespace
+			switch {
+			case allNamespaces:
+				ns = ""
+			case ns == "":
+				ns = p.Namespace
+			}
+
+			// The kinds that could be listed are printed, even if others failed.
+			t, listErr := table.Table(cmd.Context(), p.Dynamic, dt, version, ns)
+			if t == nil {
+				return listErr
+			}
+			if len(t.Rows) == 0 {
+				if listErr != nil {
+					return listErr
+				}
+				if ns == "" {
+					fmt.Fprintln(cmd.ErrOrStderr(), "No resources found")
+				} else {
+					fmt.Fprintf(cmd.ErrOrStderr(), "No resources found in %s namespace.\n", ns)
+				}
+				return nil
+			}
+			if err := printTable(cmd.OutOrStdout(), t, printOptions{
+				wide:          output == "wide",
+				withNamespace: allNamespaces,
+			}); err != nil {
+				return err
+			}
+			return listErr
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "The namespace to list instances in, defaults to the namespace of the current context.")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the instances in all namespaces.")
+	cmd.Flags().StringVar(&version, "version", "", "The version of the duck type, defaults to the latest version.")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format, wide also prints the columns with a priority.")
+
+	root.AddCommand(cmd)
+}
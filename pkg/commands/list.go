@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"knative.dev/pkg/apis"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// listColumns are the columns of the list command.
+var listColumns = []metav1.TableColumnDefinition{
+	{Name: "Name", Type: "string", Format: "name"},
+	{Name: "Duck", Type: "string"},
+	{Name: "Versions", Type: "string"},
+	{Name: "Ducks", Type: "integer"},
+	{Name: "Ready", Type: "string"},
+	{Name: "Age", Type: "date"},
+}
+
+func addListCmd(root *cobra.Command, p *Params) {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the duck types installed in the cluster.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := p.Client.DiscoveryV1alpha1().ClusterDuckTypes().List(cmd.Context(), metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			table := &metav1.Table{ColumnDefinitions: listColumns}
+			for _, dt := range list.Items {
+				table.Rows = append(table.Rows, listRow(&dt))
+			}
+			return printTable(cmd.OutOrStdout(), table, printOptions{})
+		},
+	}
+
+	root.AddCommand(cmd)
+}
+
+// listRow renders a ClusterDuckType as a row of the list command.
+func listRow(dt *v1alpha1.ClusterDuckType) metav1.TableRow {
+	versions := make([]string, 0, len(dt.Spec.Versions))
+	for _, dv := range dt.Spec.Versions {
+		versions = append(versions, dv.Name)
+	}
+
+	ready := "Unknown"
+	if cond := dt.Status.GetCondition(apis.ConditionReady); cond != nil {
+		ready = string(cond.Status)
+	}
+
+	return metav1.TableRow{
+		Cells: []interface{}{
+			dt.Name,
+			dt.Spec.Names.Name,
+			strings.Join(versions, ","),
+			dt.Status.DuckCount,
+			ready,
+			age(dt.CreationTimestamp),
+		},
+	}
+}
+
+// age formats the time passed since t like kubectl does.
+func age(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now().Sub(t.Time))
+}
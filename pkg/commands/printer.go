@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// printOptions control how a table is printed.
+type printOptions struct {
+	// wide prints the columns with a priority above zero.
+	wide bool
+	// withNamespace adds a column for the namespace of the row objects.
+	withNamespace bool
+}
+
+// printTable prints the table like kubectl does, in tab aligned columns with
+// upper case headers.
+func printTable(out io.Writer, table *metav1.Table, opts printOptions) error {
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+
+	var columns []int
+	var headers []string
+	if opts.withNamespace {
+		headers = append(headers, "NAMESPACE")
+	}
+	for i, col := range table.ColumnDefinitions {
+		if col.Priority > 0 && !opts.wide {
+			continue
+		}
+		columns = append(columns, i)
+		headers = append(headers, strings.ToUpper(col.Name))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range table.Rows {
+		var cells []string
+		if opts.withNamespace {
+			cells = append(cells, rowNamespace(row))
+		}
+		for _, i := range columns {
+			var cell interface{}
+			if i < len(row.Cells) {
+				cell = row.Cells[i]
+			}
+			cells = append(cells, formatCell(cell))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// rowNamespace returns the namespace of the object of the row, if known.
+func rowNamespace(row metav1.TableRow) string {
+	if m, ok := row.Object.Object.(metav1.Object); ok {
+		return m.GetNamespace()
+	}
+	return ""
+}
+
+// formatCell formats a cell of a table, missing values are shown as <none>.
+func formatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// whichColumns are the columns of the which command.
+var whichColumns = []metav1.TableColumnDefinition{
+	{Name: "Duck Type", Type: "string", Format: "name"},
+	{Name: "Duck", Type: "string"},
+	{Name: "Version", Type: "string"},
+	{Name: "Kind", Type: "string"},
+	{Name: "API Version", Type: "string"},
+}
+
+func addWhichCmd(root *cobra.Command, p *Params) {
+	var cmd = &cobra.Command{
+		Use:   "which <kind>",
+		Short: "Show the duck types a kind implements.",
+		Long: `Show the duck types a kind implements. The kind can be given by its kind or
+resource name, optionally qualified by its group, for example Service,
+services or services.serving.knative.dev.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := p.Client.DiscoveryV1alpha1().ClusterDuckTypes().List(cmd.Context(), metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			table := &metav1.Table{ColumnDefinitions: whichColumns}
+			for _, dt := range list.Items {
+				table.Rows = append(table.Rows, whichRows(&dt, args[0])...)
+			}
+			if len(table.Rows) == 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "No duck types found for %s.\n", args[0])
+				return nil
+			}
+			return printTable(cmd.OutOrStdout(), table, printOptions{})
+		},
+	}
+
+	root.AddCommand(cmd)
+}
+
+// whichRows renders the ducks of the ClusterDuckType matching the kind as rows
+// of the which command, ordered by version.
+func whichRows(dt *v1alpha1.ClusterDuckType, kind string) []metav1.TableRow {
+	name, group := kind, ""
+	if i := strings.Index(kind, "."); i >= 0 {
+		name, group = kind[:i], kind[i+1:]
+	}
+
+	versions := make([]string, 0, len(dt.Status.Ducks))
+	for version := range dt.Status.Ducks {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	var rows []metav1.TableRow
+	for _, version := range versions {
+		for _, meta := range dt.Status.Ducks[version] {
+			if !strings.EqualFold(meta.Kind, name) && meta.Resource != strings.ToLower(name) {
+				continue
+			}
+			if group != "" && meta.Group() != group {
+				continue
+			}
+			rows = append(rows, metav1.TableRow{
+				Cells: []interface{}{dt.Name, dt.Spec.Names.Name, version, meta.Kind, meta.APIVersion},
+			})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Inventory keeps track of the instances of the resources that implement
+// ducks. Resources are tracked on behalf of an owner, usually the key of the
+// duck type that found them, and are shared between owners.
+type Inventory interface {
+	// Track sets the resources tracked on behalf of owner. Resources that
+	// were tracked for owner before and are no longer in gvrs are released.
+	Track(owner string, gvrs []schema.GroupVersionResource)
+
+	// Forget releases all the resources tracked on behalf of owner.
+	Forget(owner string)
+
+	// List returns the instances of gvr. The second return value is false
+	// if gvr is not tracked or its instances are not known yet.
+	List(gvr schema.GroupVersionResource) ([]*unstructured.Unstructured, bool)
+}
+
+// ChangeFunc is called with the owners of a resource when its instances are
+// first known, when an instance of the resource is added or deleted, or when
+// its readiness changes.
+type ChangeFunc func(owners []string)
+
+// NewInventory creates an Inventory that watches resources with dynamic
+// informers. onChange is called when the instances of a tracked resource
+// change. Informers are stopped when ctx is done.
+func NewInventory(ctx context.Context, client dynamic.Interface, onChange ChangeFunc) Inventory {
+	return &inventory{
+		ctx:       ctx,
+		client:    client,
+		onChange:  onChange,
+		resources: make(map[schema.GroupVersionResource]*tracked),
+	}
+}
+
+type inventory struct {
+	ctx      context.Context
+	client   dynamic.Interface
+	onChange ChangeFunc
+
+	m sync.Mutex
+	// resources maps resources to their informers.
+	resources map[schema.GroupVersionResource]*tracked
+}
+
+// tracked is a running informer and the owners of its resource.
+type tracked struct {
+	informer cache.SharedIndexInformer
+	stop     chan struct{}
+	owners   sets.String
+}
+
+// Check that inventory implements Inventory.
+var _ Inventory = (*inventory)(nil)
+
+// Track implements Inventory.Track
+func (i *inventory) Track(owner string, gvrs []schema.GroupVersionResource) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	wanted := make(map[schema.GroupVersionResource]bool, len(gvrs))
+	for _, gvr := range gvrs {
+		wanted[gvr] = true
+		t, found := i.resources[gvr]
+		if !found {
+			t = i.start(gvr)
+			i.resources[gvr] = t
+		}
+		t.owners.Insert(owner)
+	}
+
+	for gvr, t := range i.resources {
+		if t.owners.Has(owner) && !wanted[gvr] {
+			i.release(owner, gvr, t)
+		}
+	}
+}
+
+// Forget implements Inventory.Forget
+func (i *inventory) Forget(owner string) {
+	i.Track(owner, nil)
+}
+
+// List implements Inventory.List
+func (i *inventory) List(gvr schema.GroupVersionResource) ([]*unstructured.Unstructured, bool) {
+	i.m.Lock()
+	t, found := i.resources[gvr]
+	i.m.Unlock()
+
+	if !found || !t.informer.HasSynced() {
+		return nil, false
+	}
+
+	objs := t.informer.GetStore().List()
+	instances := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			instances = append(instances, u)
+		}
+	}
+	return instances, true
+}
+
+// start runs a new informer for gvr. Must be called with the lock held.
+func (i *inventory) start(gvr schema.GroupVersionResource) *tracked {
+	informer := dynamicinformer.NewFilteredDynamicInformer(i.client, gvr, metav1.NamespaceAll, 0, cache.Indexers{}, nil).Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { i.changed(gvr) },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if readinessChanged(oldObj, newObj) {
+				i.changed(gvr)
+			}
+		},
+		DeleteFunc: func(interface{}) { i.changed(gvr) },
+	})
+
+	t := &tracked{
+		informer: informer,
+		stop:     make(chan struct{}),
+		owners:   sets.NewString(),
+	}
+	go func() {
+		select {
+		case <-i.ctx.Done():
+		case <-t.stop:
+			return
+		}
+		i.m.Lock()
+		defer i.m.Unlock()
+		if i.resources[gvr] == t {
+			close(t.stop)
+			delete(i.resources, gvr)
+		}
+	}()
+	go informer.Run(t.stop)
+	// The owners are notified once the instances are known, even if there
+	// are none and no handler is called.
+	go func() {
+		if cache.WaitForCacheSync(t.stop, informer.HasSynced) {
+			i.changed(gvr)
+		}
+	}()
+	return t
+}
+
+// release removes owner from the owners of gvr, and stops the informer of gvr
+// once nobody owns it. Must be called with the lock held.
+func (i *inventory) release(owner string, gvr schema.GroupVersionResource, t *tracked) {
+	t.owners.Delete(owner)
+	if t.owners.Len() == 0 {
+		close(t.stop)
+		delete(i.resources, gvr)
+	}
+}
+
+// changed notifies the owners of gvr.
+func (i *inventory) changed(gvr schema.GroupVersionResource) {
+	if i.onChange == nil {
+		return
+	}
+	i.m.Lock()
+	t, found := i.resources[gvr]
+	var owners []string
+	if found {
+		owners = t.owners.List()
+	}
+	i.m.Unlock()
+
+	if len(owners) > 0 {
+		i.onChange(owners)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var (
+	ducks = schema.GroupVersionResource{Group: "north.america", Version: "v1", Resource: "ducks"}
+	swans = schema.GroupVersionResource{Group: "north.america", Version: "v1", Resource: "swans"}
+	geese = schema.GroupVersionResource{Group: "north.america", Version: "v1", Resource: "geese"}
+)
+
+func instance(kind, namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("north.america/v1")
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func newFakeInventory(ctx context.Context, onChange ChangeFunc) Inventory {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			ducks: "DuckList",
+			swans: "SwanList",
+			geese: "GooseList",
+		},
+		instance("Duck", "pond", "mallard"),
+		instance("Duck", "lake", "teal"),
+		instance("Swan", "lake", "mute"),
+	)
+	return NewInventory(ctx, client, onChange)
+}
+
+func waitForList(t *testing.T, inv Inventory, gvr schema.GroupVersionResource) []*unstructured.Unstructured {
+	t.Helper()
+	var instances []*unstructured.Unstructured
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		var synced bool
+		instances, synced = inv.List(gvr)
+		return synced, nil
+	}); err != nil {
+		t.Fatalf("List(%s) never synced: %v", gvr, err)
+	}
+	return instances
+}
+
+func TestInventory_Track(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var m sync.Mutex
+	changed := make(map[string]bool)
+	inv := newFakeInventory(ctx, func(owners []string) {
+		m.Lock()
+		defer m.Unlock()
+		for _, owner := range owners {
+			changed[owner] = true
+		}
+	})
+
+	if _, synced := inv.List(ducks); synced {
+		t.Error("expected untracked resource to not be synced")
+	}
+
+	inv.Track("swimmers", []schema.GroupVersionResource{ducks})
+	if got := waitForList(t, inv, ducks); len(got) != 2 {
+		t.Errorf("expected 2 ducks, got %d", len(got))
+	}
+
+	inv.Track("flyers", []schema.GroupVersionResource{ducks, swans})
+	if got := waitForList(t, inv, swans); len(got) != 1 {
+		t.Errorf("expected 1 swan, got %d", len(got))
+	}
+
+	// Handlers are called asynchronously from the informers.
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		m.Lock()
+		defer m.Unlock()
+		return changed["swimmers"] && changed["flyers"], nil
+	}); err != nil {
+		t.Errorf("expected both owners to be notified: %v", err)
+	}
+
+	// Ducks are still owned by flyers.
+	inv.Forget("swimmers")
+	if _, synced := inv.List(ducks); !synced {
+		t.Error("expected ducks to still be tracked")
+	}
+
+	// Swans are no longer owned by anyone.
+	inv.Track("flyers", []schema.GroupVersionResource{ducks})
+	if _, synced := inv.List(swans); synced {
+		t.Error("expected swans to be released")
+	}
+
+	inv.Forget("flyers")
+	if _, synced := inv.List(ducks); synced {
+		t.Error("expected ducks to be released")
+	}
+}
+
+func TestInventory_Done(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	inv := newFakeInventory(ctx, nil)
+
+	inv.Track("swimmers", []schema.GroupVersionResource{ducks})
+	waitForList(t, inv, ducks)
+
+	cancel()
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		_, synced := inv.List(ducks)
+		return !synced, nil
+	}); err != nil {
+		t.Errorf("expected ducks to be released once the context is done: %v", err)
+	}
+}
+
+func TestInventory_SyncedEmpty(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notified := make(chan []string, 1)
+	inv := newFakeInventory(ctx, func(owners []string) {
+		select {
+		case notified <- owners:
+		default:
+		}
+	})
+
+	// There are no geese, no handler is called but the owner still learns
+	// that they are known.
+	inv.Track("honkers", []schema.GroupVersionResource{geese})
+	select {
+	case owners := <-notified:
+		if len(owners) != 1 || owners[0] != "honkers" {
+			t.Errorf("notified %v, want honkers", owners)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the owner to be notified once the geese are synced")
+	}
+	if got, synced := inv.List(geese); !synced || len(got) != 0 {
+		t.Errorf("List(geese) = %d instances, synced %v, want none and synced", len(got), synced)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// Readiness returns the status of the Ready condition of an instance read as
+// a duckv1.KResource, and the condition itself if the instance has one. The
+// status is Unknown if the instance has no Ready condition, or if its status
+// reports an observed generation older than the generation of its spec.
+func Readiness(u *unstructured.Unstructured) (corev1.ConditionStatus, *apis.Condition) {
+	kr := &duckv1.KResource{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, kr); err != nil {
+		return corev1.ConditionUnknown, nil
+	}
+
+	cond := kr.Status.GetCondition(apis.ConditionReady)
+	if cond == nil {
+		return corev1.ConditionUnknown, nil
+	}
+	if kr.Status.ObservedGeneration != 0 && kr.Status.ObservedGeneration < kr.Generation {
+		return corev1.ConditionUnknown, cond
+	}
+	return cond.Status, cond
+}
+
+// readinessChanged reports whether the Ready condition differs between two
+// versions of an instance.
+func readinessChanged(oldObj, newObj interface{}) bool {
+	ou, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	nu, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	oldStatus, oldCond := Readiness(ou)
+	newStatus, newCond := Readiness(nu)
+	if oldStatus != newStatus {
+		return true
+	}
+	if oldCond == nil || newCond == nil {
+		return oldCond != newCond
+	}
+	return oldCond.Reason != newCond.Reason || oldCond.Message != newCond.Message ||
+		!oldCond.LastTransitionTime.Inner.Equal(&newCond.LastTransitionTime.Inner)
+}
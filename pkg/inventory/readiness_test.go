@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func withReady(u *unstructured.Unstructured, status, reason string) *unstructured.Unstructured {
+	_ = unstructured.SetNestedSlice(u.Object, []interface{}{
+		map[string]interface{}{
+			"type":   "Ready",
+			"status": status,
+			"reason": reason,
+		},
+	}, "status", "conditions")
+	return u
+}
+
+func withGenerations(u *unstructured.Unstructured, generation, observed int64) *unstructured.Unstructured {
+	u.SetGeneration(generation)
+	_ = unstructured.SetNestedField(u.Object, observed, "status", "observedGeneration")
+	return u
+}
+
+func TestReadiness(t *testing.T) {
+	tests := map[string]struct {
+		instance *unstructured.Unstructured
+		want     corev1.ConditionStatus
+	}{
+		"no conditions": {
+			instance: instance("Duck", "pond", "mallard"),
+			want:     corev1.ConditionUnknown,
+		},
+		"ready": {
+			instance: withReady(instance("Duck", "pond", "mallard"), "True", ""),
+			want:     corev1.ConditionTrue,
+		},
+		"not ready": {
+			instance: withReady(instance("Duck", "pond", "mallard"), "False", "Molting"),
+			want:     corev1.ConditionFalse,
+		},
+		"ready at the latest generation": {
+			instance: withGenerations(withReady(instance("Duck", "pond", "mallard"), "True", ""), 2, 2),
+			want:     corev1.ConditionTrue,
+		},
+		"ready at an old generation": {
+			instance: withGenerations(withReady(instance("Duck", "pond", "mallard"), "True", ""), 2, 1),
+			want:     corev1.ConditionUnknown,
+		},
+		"bad status": {
+			instance: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": "ready",
+			}},
+			want: corev1.ConditionUnknown,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got, _ := Readiness(tc.instance); got != tc.want {
+				t.Errorf("Readiness() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadinessChanged(t *testing.T) {
+	tests := map[string]struct {
+		old  *unstructured.Unstructured
+		new  *unstructured.Unstructured
+		want bool
+	}{
+		"no conditions": {
+			old:  instance("Duck", "pond", "mallard"),
+			new:  instance("Duck", "pond", "mallard"),
+			want: false,
+		},
+		"same condition": {
+			old:  withReady(instance("Duck", "pond", "mallard"), "False", "Molting"),
+			new:  withReady(instance("Duck", "pond", "mallard"), "False", "Molting"),
+			want: false,
+		},
+		"became ready": {
+			old:  withReady(instance("Duck", "pond", "mallard"), "False", "Molting"),
+			new:  withReady(instance("Duck", "pond", "mallard"), "True", ""),
+			want: true,
+		},
+		"new reason": {
+			old:  withReady(instance("Duck", "pond", "mallard"), "False", "Molting"),
+			new:  withReady(instance("Duck", "pond", "mallard"), "False", "Migrating"),
+			want: true,
+		},
+		"gained a condition": {
+			old:  instance("Duck", "pond", "mallard"),
+			new:  withReady(instance("Duck", "pond", "mallard"), "Unknown", ""),
+			want: true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := readinessChanged(tc.old, tc.new); got != tc.want {
+				t.Errorf("readinessChanged() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
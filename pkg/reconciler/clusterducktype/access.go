@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// subjectVerbs are the verbs the Subject of a duck type Role is expected to
+// be allowed to perform on the ducks.
+var subjectVerbs = []string{"get", "list", "watch"}
+
+// ReviewAccess checks with SubjectAccessReviews whether the subject can
+// perform each of subjectVerbs on the ducks, and records the results in
+// AccessibleBySubject. Each resource is reviewed once per verb. If namespace
+// is set, the access to namespaced ducks is reviewed in that namespace only.
+func ReviewAccess(ctx context.Context, client kubernetes.Interface, subject *rbacv1.Subject, namespace string, ducks map[string][]v1alpha1.ResourceMeta) error {
+	reviewed := make(map[authorizationv1.ResourceAttributes]bool)
+	for _, metas := range ducks {
+		for i := range metas {
+			meta := &metas[i]
+			if meta.Resource == "" {
+				continue
+			}
+			meta.AccessibleBySubject = make(map[string]bool, len(subjectVerbs))
+			for _, verb := range subjectVerbs {
+				attrs := authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    meta.Group(),
+					Version:  meta.Version(),
+					Resource: meta.Resource,
+				}
+				if meta.Scope != v1alpha1.ClusterScoped {
+					attrs.Namespace = namespace
+				}
+
+				allowed, found := reviewed[attrs]
+				if !found {
+					var err error
+					if allowed, err = reviewAccess(ctx, client, subject, attrs); err != nil {
+						return err
+					}
+					reviewed[attrs] = allowed
+				}
+				meta.AccessibleBySubject[verb] = allowed
+			}
+		}
+	}
+	return nil
+}
+
+// reviewAccess asks the API server whether the ServiceAccount subject is
+// allowed to access the resource.
+func reviewAccess(ctx context.Context, client kubernetes.Interface, subject *rbacv1.Subject, attrs authorizationv1.ResourceAttributes) (bool, error) {
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attrs,
+			User:               fmt.Sprintf("system:serviceaccount:%s:%s", subject.Namespace, subject.Name),
+			Groups: []string{
+				"system:serviceaccounts",
+				fmt.Sprintf("system:serviceaccounts:%s", subject.Namespace),
+				"system:authenticated",
+			},
+		},
+	}
+	sar, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review %s access to %s: %w", attrs.Verb, attrs.Resource, err)
+	}
+	return sar.Status.Allowed, nil
+}
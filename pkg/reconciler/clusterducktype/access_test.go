@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func TestReviewAccess(t *testing.T) {
+	subject := &rbacv1.Subject{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "controller",
+		Namespace: "knative-eventing",
+	}
+
+	client := fake.NewSimpleClientset()
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	client.PrependReactor("create", "subjectaccessreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		sar := action.(clientgotesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviews = append(reviews, sar.Spec)
+		attrs := sar.Spec.ResourceAttributes
+		// The controller can read ducks, but only get gila monsters.
+		sar.Status.Allowed = attrs.Resource == "ducks" || attrs.Verb == "get"
+		return true, sar, nil
+	})
+
+	ducks := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "north.america/v1alpha2",
+			Kind:       "Duck",
+			Resource:   "ducks",
+			Scope:      v1alpha1.NamespaceScoped,
+		}, {
+			APIVersion: "north.america/v2",
+			Kind:       "GilaMonster",
+			Resource:   "gilamonsters",
+			Scope:      v1alpha1.ClusterScoped,
+		}, {
+			APIVersion: "australia/v1",
+			Kind:       "Wombat",
+		}},
+		"v2": {{
+			APIVersion: "north.america/v1alpha2",
+			Kind:       "Duck",
+			Resource:   "ducks",
+			Scope:      v1alpha1.NamespaceScoped,
+		}},
+	}
+	if err := ReviewAccess(context.Background(), client, subject, "pond", ducks); err != nil {
+		t.Fatal("ReviewAccess() =", err)
+	}
+
+	all := map[string]bool{"get": true, "list": true, "watch": true}
+	want := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion:          "north.america/v1alpha2",
+			Kind:                "Duck",
+			Resource:            "ducks",
+			Scope:               v1alpha1.NamespaceScoped,
+			AccessibleBySubject: all,
+		}, {
+			APIVersion:          "north.america/v2",
+			Kind:                "GilaMonster",
+			Resource:            "gilamonsters",
+			Scope:               v1alpha1.ClusterScoped,
+			AccessibleBySubject: map[string]bool{"get": true, "list": false, "watch": false},
+		}, {
+			APIVersion: "australia/v1",
+			Kind:       "Wombat",
+		}},
+		"v2": {{
+			APIVersion:          "north.america/v1alpha2",
+			Kind:                "Duck",
+			Resource:            "ducks",
+			Scope:               v1alpha1.NamespaceScoped,
+			AccessibleBySubject: all,
+		}},
+	}
+	if diff := cmp.Diff(want, ducks); diff != "" {
+		t.Error("ducks (-want, +got):", diff)
+	}
+
+	// Each resource is reviewed once per verb.
+	if got, want := len(reviews), 6; got != want {
+		t.Errorf("got %d reviews, want %d", got, want)
+	}
+	for _, spec := range reviews {
+		if spec.User != "system:serviceaccount:knative-eventing:controller" {
+			t.Error("unexpected user", spec.User)
+		}
+		attrs := spec.ResourceAttributes
+		if wantNS := map[string]string{"ducks": "pond", "gilamonsters": ""}[attrs.Resource]; attrs.Namespace != wantNS {
+			t.Errorf("%s reviewed in namespace %q, want %q", attrs.Resource, attrs.Namespace, wantNS)
+		}
+	}
+}
+
+func TestReviewAccessError(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("boom")
+	})
+
+	ducks := map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "north.america/v1alpha2",
+			Kind:       "Duck",
+			Resource:   "ducks",
+		}},
+	}
+	subject := &rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "controller", Namespace: "default"}
+	if err := ReviewAccess(context.Background(), client, subject, "", ducks); err == nil {
+		t.Error("ReviewAccess() = nil, want error")
+	}
+}
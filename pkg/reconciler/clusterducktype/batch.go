@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// ResyncWindowEnvKey names the environment variable holding the window
+	// in which CRD events are batched, as a duration like "2s". Zero turns
+	// batching off.
+	ResyncWindowEnvKey = "CRD_RESYNC_WINDOW"
+
+	// DefaultResyncWindow is used when ResyncWindowEnvKey is not set.
+	DefaultResyncWindow = time.Second
+)
+
+// ResyncWindow returns the window set by ResyncWindowEnvKey, or
+// DefaultResyncWindow if it is not set.
+func ResyncWindow() (time.Duration, error) {
+	value, found := os.LookupEnv(ResyncWindowEnvKey)
+	if !found || value == "" {
+		return DefaultResyncWindow, nil
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", ResyncWindowEnvKey, value, err)
+	}
+	if window < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must not be negative", ResyncWindowEnvKey, value)
+	}
+	return window, nil
+}
+
+// Batcher batches the objects added within a window and hands them to a
+// function once the window has passed, so that a burst of events, like the
+// CRDs of a release being installed, is handled once.
+type Batcher struct {
+	window time.Duration
+	fn     func(objs []interface{})
+
+	mu    sync.Mutex
+	objs  []interface{}
+	timer *time.Timer
+}
+
+// NewBatcher creates a Batcher calling fn with the objects added within
+// window. If window is zero, fn is called for each object as it is added.
+func NewBatcher(window time.Duration, fn func(objs []interface{})) *Batcher {
+	return &Batcher{
+		window: window,
+		fn:     fn,
+	}
+}
+
+// Add adds an object to the current batch, opening a window if none is. It
+// has the signature of an event handler, see controller.HandleAll.
+func (b *Batcher) Add(obj interface{}) {
+	if b.window <= 0 {
+		b.fn([]interface{}{obj})
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objs = append(b.objs, obj)
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+}
+
+// flush hands the current batch to fn. Objects added meanwhile open a new
+// window.
+func (b *Batcher) flush() {
+	b.mu.Lock()
+	objs := b.objs
+	b.objs = nil
+	b.timer = nil
+	b.mu.Unlock()
+
+	if len(objs) > 0 {
+		b.fn(objs)
+	}
+}
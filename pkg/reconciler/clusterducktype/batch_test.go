@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResyncWindow(t *testing.T) {
+	tests := map[string]struct {
+		value   string
+		set     bool
+		want    time.Duration
+		wantErr bool
+	}{
+		"not set": {
+			want: DefaultResyncWindow,
+		},
+		"empty": {
+			set:  true,
+			want: DefaultResyncWindow,
+		},
+		"duration": {
+			value: "250ms",
+			set:   true,
+			want:  250 * time.Millisecond,
+		},
+		"off": {
+			value: "0",
+			set:   true,
+			want:  0,
+		},
+		"negative": {
+			value:   "-1s",
+			set:     true,
+			wantErr: true,
+		},
+		"not a duration": {
+			value:   "soon",
+			set:     true,
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			os.Unsetenv(ResyncWindowEnvKey)
+			if tc.set {
+				os.Setenv(ResyncWindowEnvKey, tc.value)
+			}
+			defer os.Unsetenv(ResyncWindowEnvKey)
+
+			got, err := ResyncWindow()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ResyncWindow() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ResyncWindow() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBatcher(t *testing.T) {
+	batches := make(chan []interface{}, 10)
+	b := NewBatcher(50*time.Millisecond, func(objs []interface{}) {
+		batches <- objs
+	})
+
+	// A burst is handled once.
+	b.Add("ducks")
+	b.Add("geese")
+	b.Add("swans")
+	select {
+	case got := <-batches:
+		if diff := cmp.Diff([]interface{}{"ducks", "geese", "swans"}, got); diff != "" {
+			t.Error("batch (-want, +got):", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the batch")
+	}
+
+	// Later objects open a new window.
+	b.Add("loons")
+	select {
+	case got := <-batches:
+		if diff := cmp.Diff([]interface{}{"loons"}, got); diff != "" {
+			t.Error("batch (-want, +got):", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the batch")
+	}
+
+	select {
+	case got := <-batches:
+		t.Error("unexpected batch:", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestBatcherNoWindow(t *testing.T) {
+	var got []interface{}
+	b := NewBatcher(0, func(objs []interface{}) {
+		got = append(got, objs...)
+	})
+	b.Add("ducks")
+	if diff := cmp.Diff([]interface{}{"ducks"}, got); diff != "" {
+		t.Error("handled (-want, +got):", diff)
+	}
+}
@@ -18,20 +18,27 @@ package clusterducktype
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
-	"sync"
 
 	"go.uber.org/zap"
 	"knative.dev/pkg/logging"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionslisters "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
 	"knative.dev/discovery/pkg/collection"
+	"knative.dev/discovery/pkg/inventory"
 
 	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
 	ducktypereconciler "knative.dev/discovery/pkg/client/injection/reconciler/discovery/v1alpha1/clusterducktype"
@@ -44,67 +51,202 @@ type Reconciler struct {
 	client    kubernetes.Interface
 	crdLister apiextensionslisters.CustomResourceDefinitionLister
 
-	resourceMapper collection.ResourceMapper
-	rmx            sync.Mutex
+	// clusterRoleLister finds the ClusterRoles managed for the ducks.
+	clusterRoleLister rbaclisters.ClusterRoleLister
+
+	// inventory counts the instances of the ducks. Optional.
+	inventory inventory.Inventory
+
+	// openAPI lists the kinds served by the API server, to discover the
+	// built-in kinds matching the schema of a duck. Optional.
+	openAPI openAPISource
+
+	// mappers keeps the resource mapper in sync with the resources served by
+	// the cluster.
+	mappers *ResourceMapperSync
 }
 
 // Check that our Reconciler implements Interface
 var _ ducktypereconciler.Interface = (*Reconciler)(nil)
 
+// Check that our Reconciler releases the inventory of deleted resources.
+var _ reconciler.OnDeletionInterface = (*Reconciler)(nil)
+
 // ReconcileKind implements Interface
 func (r *Reconciler) ReconcileKind(ctx context.Context, dt *v1alpha1.ClusterDuckType) reconciler.Event {
 	// Make a safe copy of the resource mapper.
-	r.rmx.Lock()
-	rm := r.resourceMapper.DeepCopy()
-	r.rmx.Unlock()
+	rm, err := r.mappers.Mapper()
+	if err != nil {
+		// Keep hunting, the ducks of the missing group versions are not found.
+		dt.Status.MarkDiscoveryUnhealthy("DiscoveryFailed", "Ducks may be missing: %v", err)
+	} else {
+		dt.Status.MarkDiscoveryHealthy()
+	}
 
 	clusterRole, err := r.getAggregatingClusterRole(ctx, dt)
-	if err != nil {
+	var ambiguous *ambiguousRoleError
+	if apierrs.IsNotFound(err) {
+		// Keep hunting, the ducks are reported as not accessible.
+		clusterRole = nil
+		dt.Status.MarkRoleUnresolved("RoleNotFound", "ClusterRole %q not found", dt.Spec.Role.RoleRef.Name)
+	} else if errors.As(err, &ambiguous) {
+		// Keep hunting, a role has to be picked with Spec.Role.
+		clusterRole = nil
+		dt.Status.MarkRoleUnresolved("AmbiguousRole", "ClusterRoles %s all aggregate the ducks, set spec.role to pick one", strings.Join(ambiguous.names, ", "))
+	} else if err != nil {
+		dt.Status.MarkRoleUnresolved("RoleLookupFailed", "Unable to get the aggregating ClusterRole: %v", err)
 		return err
+	} else if clusterRole == nil {
+		dt.Status.MarkRoleResolvedWithReason("NoRole", "No aggregating ClusterRole found, ducks are not checked for access")
+	} else {
+		dt.Status.MarkRoleResolved()
 	}
 	// Set up this instance of a duck hunter.
 	hunter := collection.NewDuckHunter(rm, dt.Spec.Versions, &collection.DuckFilters{
 		DuckLabel:         fmt.Sprintf("%s/%s", dt.Spec.Group, dt.Spec.Names.Singular),
-		DuckVersionPrefix: fmt.Sprintf("%s.%s", dt.Spec.Names.Plural, dt.Spec.Group),
+		DuckVersionPrefix: duckVersionPrefix(dt),
 	}, clusterRole,
 	)
 
 	// By query
 
-	for _, st := range dt.Spec.Selectors {
-		crds, err := r.getCRDsWith(st.LabelSelector)
-		if err != nil {
-			// TODO: this should be a condition that reports back that RBAC is incorrect for getting CRDs?
-			return err
-		}
-		hunter.AddCRDs(crds)
+	crds, err := SelectCRDs(&dt.Spec, r.getCRDsWith)
+	if err != nil {
+		dt.Status.MarkCRDsNotDiscovered("CRDListFailed", "Unable to list CRDs with %v", err)
+		return err
 	}
+	hunter.AddCRDs(crds)
 
 	// By ref
 
-	for _, dv := range dt.Spec.Versions {
-		for _, ref := range dv.Refs {
-			// TODO we should query and test that the Ref is installed and works on this cluster.
-			if err := hunter.AddRef(dv.Name, ref); err != nil {
-				logging.FromContext(ctx).Warnw("unable to add resource ref", zap.Error(err))
-			}
+	dt.Status.UnresolvedRefs = AddRefs(ctx, hunter, dt.Spec.Versions)
+	var refsEvent reconciler.Event
+	if len(dt.Status.UnresolvedRefs) > 0 {
+		msg := UnresolvedRefsMessage(dt.Status.UnresolvedRefs)
+		dt.Status.MarkRefsUnresolved("RefsNotFound", "Unable to resolve refs: %s", msg)
+		refsEvent = reconciler.NewEvent(corev1.EventTypeWarning, "RefsNotFound", "Unable to resolve refs: %s", msg)
+	} else if mismatches := hunter.RefScopeMismatches(); len(mismatches) > 0 {
+		dt.Status.MarkRefsResolvedWithReason("ScopeMismatch", "Scope of refs taken from the cluster: %s", collection.RefScopeMismatchesMessage(mismatches))
+	} else {
+		dt.Status.MarkRefsResolved()
+	}
+
+	// By schema, the kinds already found by ref are skipped.
+
+	if dt.Spec.DiscoverBuiltIns && r.openAPI != nil {
+		kinds, err := r.openAPI.Kinds(ctx)
+		if err != nil {
+			dt.Status.MarkCRDsNotDiscovered("OpenAPIFailed", "Unable to read the OpenAPI documents: %v", err)
+			return err
+		}
+		all, err := r.crdLister.List(labels.Everything())
+		if err != nil {
+			dt.Status.MarkCRDsNotDiscovered("CRDListFailed", "Unable to list CRDs with %v", err)
+			return err
 		}
+		hunter.AddOpenAPIKinds(builtInKinds(kinds, all))
 	}
+	dt.Status.MarkCRDsDiscovered()
 
 	ducks := hunter.Ducks()
+	if dt.Spec.Role != nil && dt.Spec.Role.Subject != nil {
+		if err := ReviewAccess(ctx, r.client, dt.Spec.Role.Subject, "", ducks); err != nil {
+			dt.Status.MarkRoleUnresolved("AccessReviewFailed", "Unable to review the access of the subject: %v", err)
+			return err
+		}
+	}
+
+	if err := r.reconcileManagedRole(ctx, dt, ducks); err != nil {
+		dt.Status.MarkRoleUnresolved("ManagedRoleFailed", "Unable to maintain the managed ClusterRole: %v", err)
+		return err
+	}
 
 	if clusterRole != nil && clusterRole.AggregationRule != nil {
 		dt.Status.ClusterRoleAggregationRule = *clusterRole.AggregationRule
 	}
 	dt.Status.Ducks = ducks
 	dt.Status.DuckCount = DuckCount(dt.Status.Ducks)
-	dt.Status.MarkReady()
+	dt.Status.NonConformingDucks = hunter.NonConformingDucks()
+	dt.Status.SkippedCRDs = hunter.SkippedCRDs()
+	if dt.Spec.CountInstances && r.inventory != nil {
+		counts, readiness, uncounted, unsynced, err := takeInventory(r.inventory, dt.Name, ducks, func(gvr schema.GroupVersionResource) (bool, error) {
+			return canListAll(ctx, r.client, gvr)
+		})
+		if err != nil {
+			dt.Status.MarkInstancesNotCounted("AccessReviewFailed", "Unable to review the access of the controller: %v", err)
+			return err
+		}
+		dt.Status.Instances, dt.Status.Readiness = counts, readiness
+		switch {
+		case len(uncounted) > 0:
+			dt.Status.MarkInstancesNotCounted("KindsNotListable", "Instances not counted for %s", strings.Join(uncounted, "; "))
+		case len(unsynced) > 0:
+			// The owner is notified once they are synced.
+			dt.Status.MarkInstancesCountUnknown("NotSynced", "Instances not known yet for %s", strings.Join(unsynced, ", "))
+		default:
+			dt.Status.MarkInstancesCounted()
+		}
+	} else {
+		if r.inventory != nil {
+			r.inventory.Forget(dt.Name)
+		}
+		dt.Status.Instances, dt.Status.Readiness = nil, nil
+		dt.Status.ClearInstancesCounted()
+	}
+	return refsEvent
+}
+
+// ObserveDeletion implements reconciler.OnDeletionInterface
+func (r *Reconciler) ObserveDeletion(ctx context.Context, key types.NamespacedName) error {
+	if r.inventory != nil {
+		r.inventory.Forget(key.Name)
+	}
 	return nil
 }
 
+// AddRefs adds the refs of each duck version to the hunter, and returns the
+// refs that could not be resolved mapped by duck version, or nil if all refs
+// were resolved.
+func AddRefs(ctx context.Context, hunter collection.DuckHunter, versions []v1alpha1.DuckVersion) map[string][]v1alpha1.UnresolvedResourceRef {
+	var unresolved map[string][]v1alpha1.UnresolvedResourceRef
+	for _, dv := range versions {
+		for _, ref := range dv.Refs {
+			if err := hunter.AddRef(dv.Name, ref); err != nil {
+				logging.FromContext(ctx).Warnw("unable to add resource ref", zap.Error(err))
+				if unresolved == nil {
+					unresolved = make(map[string][]v1alpha1.UnresolvedResourceRef)
+				}
+				unresolved[dv.Name] = append(unresolved[dv.Name], v1alpha1.UnresolvedResourceRef{
+					ResourceRef: ref,
+					Error:       err.Error(),
+				})
+			}
+		}
+	}
+	return unresolved
+}
+
+// UnresolvedRefsMessage joins the errors of the unresolved refs, ordered by
+// duck version.
+func UnresolvedRefsMessage(unresolved map[string][]v1alpha1.UnresolvedResourceRef) string {
+	versions := make([]string, 0, len(unresolved))
+	for version := range unresolved {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	errs := make([]string, 0)
+	for _, version := range versions {
+		for _, ref := range unresolved[version] {
+			errs = append(errs, fmt.Sprintf("%s: %s", version, ref.Error))
+		}
+	}
+	return strings.Join(errs, "; ")
+}
+
 // getAggregatingClusterRole fetches the ClusterRole specified by Spec.Role.RoleRef
-//   if not set, it will default to using the first LabelSelector in Spec.Selectors to
-//   match any ClusterRole with a matching AggregationRule
+//   if not set, it will look for the ClusterRole whose AggregationRule selects
+//   the labels of the ducks matched by Spec.Selectors
 func (r *Reconciler) getAggregatingClusterRole(ctx context.Context, dt *v1alpha1.ClusterDuckType) (*rbacv1.ClusterRole, error) {
 	if dt.Spec.Role != nil && dt.Spec.Role.RoleRef != nil {
 		return r.client.RbacV1().ClusterRoles().Get(ctx, dt.Spec.Role.RoleRef.Name, metav1.GetOptions{})
@@ -114,34 +256,17 @@ func (r *Reconciler) getAggregatingClusterRole(ctx context.Context, dt *v1alpha1
 		if err != nil {
 			return nil, err
 		}
-		return getClusterRoleWithAggregationLabel(ctx, dt.Spec.Selectors[0].LabelSelector, clusterRoles.Items)
+		return getClusterRoleAggregating(dt.Spec.Selectors, clusterRoles.Items)
 	} else {
 		return nil, nil
 	}
 }
 
-// getClusterRoleWithAggregationLabel fetches a ClusterRole with a AggregationRule that matches the labelSelector
-func getClusterRoleWithAggregationLabel(ctx context.Context, labelSelector string, clusterRoles []rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
-	label := strings.Split(labelSelector, "=")[0]
-	if label == "" {
-		return nil, nil
-	}
-	for _, cr := range clusterRoles {
-		if cr.AggregationRule != nil {
-			for _, selectors := range cr.AggregationRule.ClusterRoleSelectors {
-				if _, ok := selectors.MatchLabels[label]; ok {
-					return &cr, nil
-				}
-			}
-		}
-	}
-	return nil, nil
-}
-
-// getCRDsWith returns CRDs labeled as given.
-// labelSelector should be in the form "<group>/<names.singular>=true"
-func (r *Reconciler) getCRDsWith(labelSelector string) ([]*apiextensionsv1.CustomResourceDefinition, error) {
-	ls, err := labels.Parse(labelSelector)
+// getCRDsWith returns CRDs labeled as given, narrowed by the annotation
+// selector and field filters of the selector.
+// st.LabelSelector should be in the form "<group>/<names.singular>=true"
+func (r *Reconciler) getCRDsWith(st v1alpha1.CustomResourceDefinitionSelector) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	ls, err := labels.Parse(st.LabelSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -151,22 +276,7 @@ func (r *Reconciler) getCRDsWith(labelSelector string) ([]*apiextensionsv1.Custo
 		return nil, err
 	}
 
-	return list, nil
-}
-
-// resyncResourceMapper will make a call to the Kubernetes APIServer to request
-// the full list of resources on this cluster and then process the list to
-// create a lookup table between GroupVersions, Kinds and Resources.
-func (r *Reconciler) resyncResourceMapper(ctx context.Context) {
-	_, apiResources, err := r.client.Discovery().ServerGroupsAndResources()
-	if err != nil {
-		logging.FromContext(ctx).Errorf("Failed to resync resource mapper.", zap.Error(err))
-		return
-	}
-
-	r.rmx.Lock()
-	r.resourceMapper = collection.NewResourceMapper(apiResources)
-	r.rmx.Unlock()
+	return FilterCRDs(list, st)
 }
 
 // DuckCount de-dupes the number of ducks inside the mapped collection of found
@@ -18,9 +18,13 @@ package clusterducktype
 
 import (
 	"context"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgotesting "k8s.io/client-go/testing"
 	"knative.dev/discovery/pkg/client/injection/reconciler/discovery/v1alpha1/clusterducktype"
 	"knative.dev/discovery/pkg/collection"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
@@ -101,6 +105,29 @@ var apiGroups = []*metav1.APIResourceList{
 	},
 }
 
+// fakeOpenAPI serves the kinds of the OpenAPI documents in testdata/openapi.
+type fakeOpenAPI struct{}
+
+func (fakeOpenAPI) Kinds(ctx context.Context) ([]collection.OpenAPIKind, error) {
+	files, err := filepath.Glob("testdata/openapi/*.json")
+	if err != nil {
+		return nil, err
+	}
+	kinds := make([]collection.OpenAPIKind, 0)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		docKinds, err := collection.ParseOpenAPIKinds(data)
+		if err != nil {
+			return nil, err
+		}
+		kinds = append(kinds, docKinds...)
+	}
+	return kinds, nil
+}
+
 func TestMain(m *testing.M) {
 	featured.Run(m)
 }
@@ -108,10 +135,15 @@ func TestMain(m *testing.M) {
 func TestReconcileKind(t *testing.T) {
 	featured.TestReconcileKind(t, "ClusterDuckType", MakeFactory(func(ctx context.Context, listers *Listers, watcher configmap.Watcher) controller.Reconciler {
 		r := &Reconciler{
-			client:         fakekubeclient.Get(ctx),
-			crdLister:      listers.GetCustomResourceDefinitionLister(),
-			resourceMapper: collection.NewResourceMapper(apiGroups),
+			client:            fakekubeclient.Get(ctx),
+			crdLister:         listers.GetCustomResourceDefinitionLister(),
+			clusterRoleLister: listers.GetClusterRoleLister(),
+			mappers: NewResourceMapperSync(&fakediscovery.FakeDiscovery{
+				Fake: &clientgotesting.Fake{Resources: apiGroups},
+			}, nil),
+			openAPI: fakeOpenAPI{},
 		}
+		r.mappers.Resync(ctx)
 		return clusterducktype.NewReconciler(ctx, logging.FromContext(ctx),
 			client.Get(ctx), listers.GetClusterDuckTypeLister(),
 			controller.GetEventRecorder(ctx), r)
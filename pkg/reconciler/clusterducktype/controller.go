@@ -19,12 +19,20 @@ package clusterducktype
 import (
 	"context"
 
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
 	ducktypeinformer "knative.dev/discovery/pkg/client/injection/informers/discovery/v1alpha1/clusterducktype"
 	ducktypereconciler "knative.dev/discovery/pkg/client/injection/reconciler/discovery/v1alpha1/clusterducktype"
+	"knative.dev/discovery/pkg/inventory"
 	crdinformer "knative.dev/pkg/client/injection/apiextensions/informers/apiextensions/v1/customresourcedefinition"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	clusterroleinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/clusterrole"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 )
 
@@ -37,24 +45,94 @@ func NewController(
 
 	ducktypeInformer := ducktypeinformer.Get(ctx)
 	crdInformer := crdinformer.Get(ctx)
+	clusterRoleInformer := clusterroleinformer.Get(ctx)
 
 	r := &Reconciler{
-		client:    kubeclient.Get(ctx),
-		crdLister: crdInformer.Lister(),
+		client:            kubeclient.Get(ctx),
+		crdLister:         crdInformer.Lister(),
+		clusterRoleLister: clusterRoleInformer.Lister(),
+	}
+	// Built-in kinds are discovered from the OpenAPI documents, when the
+	// client can request them.
+	var openAPI *openAPIKinds
+	if client := kubeclient.Get(ctx).Discovery().RESTClient(); client != nil {
+		openAPI = &openAPIKinds{client: client}
+		r.openAPI = openAPI
 	}
-	r.resyncResourceMapper(ctx)
 	impl := ducktypereconciler.NewImpl(ctx, r)
 
+	// Map the resources served by the cluster, and resync when the group
+	// versions that could not be discovered come back. The kinds of the
+	// OpenAPI documents are read again with each discovery.
+	r.mappers = NewResourceMapperSync(kubeclient.Get(ctx).Discovery(), func() {
+		impl.GlobalResync(ducktypeInformer.Informer())
+	})
+	r.mappers.openAPI = openAPI
+	r.mappers.Resync(ctx)
+
 	logger.Info("Setting up event handlers.")
 
 	ducktypeInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
-	// Watch custom resource definitions.
-	grDt := func(obj interface{}) {
-		r.resyncResourceMapper(ctx)
-		impl.GlobalResync(ducktypeInformer.Informer())
+	// Restore the managed ClusterRoles when they are changed or deleted.
+	clusterRoleInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&v1alpha1.ClusterDuckType{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Watch the instances of the ducks, and recount them when they come and go.
+	r.inventory = inventory.NewInventory(ctx, dynamicclient.Get(ctx), func(owners []string) {
+		for _, owner := range owners {
+			impl.EnqueueKey(types.NamespacedName{Name: owner})
+		}
+	})
+
+	// Watch custom resource definitions. The events of a burst, like the CRDs
+	// of a release being installed, are batched so the resources are
+	// rediscovered once.
+	window, err := ResyncWindow()
+	if err != nil {
+		logger.Fatalw("Unable to read the CRD resync window", zap.Error(err))
+	}
+	// Only the duck types a CRD may affect, before or after its change, are
+	// enqueued.
+	if err := ducktypeInformer.Informer().AddIndexers(cache.Indexers{crdIndex: indexByCRD}); err != nil {
+		logger.Fatalw("Unable to index ClusterDuckTypes by CRD", zap.Error(err))
 	}
-	crdInformer.Informer().AddEventHandler(controller.HandleAll(grDt))
+	grDt := NewBatcher(window, func(crds []interface{}) {
+		unhealthy := message(r.mappers.Err())
+		r.mappers.Resync(ctx)
+		if message(r.mappers.Err()) != unhealthy {
+			// The DiscoveryHealthy condition of every duck type changes.
+			impl.GlobalResync(ducktypeInformer.Informer())
+			return
+		}
+		dts, err := affectedDuckTypes(ducktypeInformer.Informer().GetIndexer(), crds)
+		if err != nil {
+			logger.Errorw("Failed to find the duck types affected by CRDs, resyncing all.", zap.Error(err))
+			impl.GlobalResync(ducktypeInformer.Informer())
+			return
+		}
+		for _, dt := range dts {
+			impl.Enqueue(dt)
+		}
+	})
+	crdInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: grDt.Add,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			grDt.Add(oldObj)
+			grDt.Add(newObj)
+		},
+		DeleteFunc: grDt.Add,
+	})
 
 	return impl
 }
+
+// message returns the message of err, or "" if err is nil.
+func message(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
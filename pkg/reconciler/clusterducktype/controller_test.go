@@ -27,6 +27,8 @@ import (
 	_ "knative.dev/discovery/pkg/client/injection/informers/discovery/v1alpha1/clusterducktype/fake"
 	_ "knative.dev/pkg/client/injection/apiextensions/informers/apiextensions/v1/customresourcedefinition/fake"
 	_ "knative.dev/pkg/client/injection/kube/client/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/rbac/v1/clusterrole/fake"
+	_ "knative.dev/pkg/injection/clients/dynamicclient/fake"
 )
 
 func TestNew(t *testing.T) {
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/discovery/pkg/collection"
+)
+
+// discoveryBackoff spaces the retries of the group versions that could not
+// be discovered.
+var discoveryBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    20,
+	Cap:      5 * time.Minute,
+}
+
+// ResourceMapperSync keeps a ResourceMapper in sync with the resources served
+// by the cluster. When some group versions cannot be discovered, like those of
+// an aggregated APIService that is down, the resources of the others are
+// mapped and the failed group versions are retried with backoff.
+type ResourceMapperSync struct {
+	client discovery.DiscoveryInterface
+	// resynced is called when a retry changed the mapper. Optional.
+	resynced func()
+	// openAPI is refreshed when the mapper is updated. Optional.
+	openAPI *openAPIKinds
+	// openAPIMissing logs once that the API server does not serve the
+	// OpenAPI v3 documents.
+	openAPIMissing sync.Once
+
+	mu     sync.Mutex
+	groups []*metav1.APIGroup
+	lists  []*metav1.APIResourceList
+	mapper collection.ResourceMapper
+	// err is the error of the last discovery, nil if every group version was
+	// discovered.
+	err    error
+	failed map[schema.GroupVersion]error
+	// generation is bumped by each full resync, so that an older retry does
+	// not overwrite it.
+	generation int
+	backoff    wait.Backoff
+	retry      *time.Timer
+}
+
+// NewResourceMapperSync creates a ResourceMapperSync with an empty mapper,
+// call Resync to fill it in. resynced is called when a retry of the failed
+// group versions changed the mapper.
+func NewResourceMapperSync(client discovery.DiscoveryInterface, resynced func()) *ResourceMapperSync {
+	return &ResourceMapperSync{
+		client:   client,
+		resynced: resynced,
+		mapper:   collection.NewResourceMapper(nil),
+		backoff:  discoveryBackoff,
+	}
+}
+
+// Mapper returns a copy of the current mapper, and the error of the last
+// discovery if some group versions could not be discovered.
+func (s *ResourceMapperSync) Mapper() (collection.ResourceMapper, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mapper.DeepCopy(), s.err
+}
+
+// Err returns the error of the last discovery if some group versions could
+// not be discovered.
+func (s *ResourceMapperSync) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Resync requests the full list of resources served by the cluster and maps
+// them. The resources of the group versions that could be discovered are
+// mapped even if others failed.
+func (s *ResourceMapperSync) Resync(ctx context.Context) {
+	s.resync(ctx)
+}
+
+// resync is Resync, reporting whether the mapper was updated.
+func (s *ResourceMapperSync) resync(ctx context.Context) bool {
+	logger := logging.FromContext(ctx)
+	groups, lists, err := s.client.ServerGroupsAndResources()
+	s.refreshOpenAPI(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+
+	var failed *discovery.ErrGroupDiscoveryFailed
+	switch {
+	case err == nil:
+		s.failed = nil
+	case errors.As(err, &failed):
+		logger.Warnw("Some group versions could not be discovered, retrying.", zap.Error(err))
+		s.failed = failed.Groups
+	default:
+		// Keep the current mapper, everything is retried.
+		logger.Errorw("Failed to resync resource mapper, retrying.", zap.Error(err))
+		s.err = err
+		s.scheduleRetry(ctx)
+		return false
+	}
+	s.groups, s.lists = groups, lists
+	s.mapper = collection.NewResourceMapperWithGroups(groups, lists)
+	s.err = failedGroupsError(s.failed)
+	s.scheduleRetry(ctx)
+	return true
+}
+
+// retryFailed requests the resources of the group versions that could not
+// be discovered, or of every group version if discovery failed altogether.
+func (s *ResourceMapperSync) retryFailed(ctx context.Context) {
+	s.mu.Lock()
+	s.retry = nil
+	generation, groups, lists, failed := s.generation, s.groups, s.lists, s.failed
+	retryAll := s.err != nil && failed == nil
+	s.mu.Unlock()
+
+	if retryAll {
+		if s.resync(ctx) {
+			s.notify()
+		}
+		return
+	}
+
+	merged := make([]*metav1.APIResourceList, 0, len(lists)+len(failed))
+	merged = append(merged, lists...)
+	stillFailed := make(map[schema.GroupVersion]error)
+	for gv := range failed {
+		list, err := s.client.ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			stillFailed[gv] = err
+			continue
+		}
+		merged = append(merged, list)
+	}
+
+	s.mu.Lock()
+	if generation != s.generation {
+		// A full resync happened in the meantime.
+		s.mu.Unlock()
+		return
+	}
+	if len(stillFailed) == 0 {
+		stillFailed = nil
+	}
+	s.lists = merged
+	s.mapper = collection.NewResourceMapperWithGroups(groups, merged)
+	s.failed = stillFailed
+	s.err = failedGroupsError(stillFailed)
+	s.scheduleRetry(ctx)
+	changed := len(stillFailed) < len(failed)
+	s.mu.Unlock()
+
+	if changed {
+		s.refreshOpenAPI(ctx)
+		s.notify()
+	}
+}
+
+// refreshOpenAPI reads the kinds of the OpenAPI documents again, if set. A
+// failure is retried by the next reconcile that needs the kinds. An API server
+// without OpenAPI v3 documents is only logged once.
+func (s *ResourceMapperSync) refreshOpenAPI(ctx context.Context) {
+	if s.openAPI == nil {
+		return
+	}
+	err := s.openAPI.Refresh(ctx)
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		s.openAPIMissing.Do(func() {
+			logging.FromContext(ctx).Warnw("The API server does not serve the OpenAPI v3 documents.", zap.Error(err))
+		})
+	default:
+		logging.FromContext(ctx).Warnw("Failed to refresh the OpenAPI kinds.", zap.Error(err))
+	}
+}
+
+// scheduleRetry schedules a retry with backoff if the last discovery failed,
+// or resets the backoff if it did not. s.mu must be held.
+func (s *ResourceMapperSync) scheduleRetry(ctx context.Context) {
+	if s.err == nil {
+		if s.retry != nil {
+			s.retry.Stop()
+			s.retry = nil
+		}
+		s.backoff = discoveryBackoff
+		return
+	}
+	if s.retry != nil {
+		// A retry is already pending.
+		return
+	}
+	s.retry = time.AfterFunc(s.backoff.Step(), func() {
+		s.retryFailed(ctx)
+	})
+}
+
+// notify calls resynced, if set.
+func (s *ResourceMapperSync) notify() {
+	if s.resynced != nil {
+		s.resynced()
+	}
+}
+
+// failedGroupsError joins the errors of the group versions that could not be
+// discovered, ordered by group version, or returns nil if there are none.
+func failedGroupsError(failed map[schema.GroupVersion]error) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	gvs := make([]string, 0, len(failed))
+	errs := make(map[string]error, len(failed))
+	for gv, err := range failed {
+		gvs = append(gvs, gv.String())
+		errs[gv.String()] = err
+	}
+	sort.Strings(gvs)
+	msgs := make([]string, 0, len(gvs))
+	for _, gv := range gvs {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", gv, errs[gv]))
+	}
+	return fmt.Errorf("unable to discover %s", strings.Join(msgs, "; "))
+}
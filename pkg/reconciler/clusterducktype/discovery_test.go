@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"knative.dev/discovery/pkg/collection"
+)
+
+// flakyDiscovery fails to discover the failing group versions, or everything
+// if down is set.
+type flakyDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	failing map[string]bool
+	down    bool
+}
+
+func (f *flakyDiscovery) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	if f.down {
+		return nil, nil, errors.New("connection refused")
+	}
+	groups, lists, err := f.FakeDiscovery.ServerGroupsAndResources()
+	if err != nil {
+		return nil, nil, err
+	}
+	served := make([]*metav1.APIResourceList, 0, len(lists))
+	failed := make(map[schema.GroupVersion]error)
+	for _, list := range lists {
+		if f.failing[list.GroupVersion] {
+			gv, _ := schema.ParseGroupVersion(list.GroupVersion)
+			failed[gv] = errors.New("the server is currently unable to handle the request")
+			continue
+		}
+		served = append(served, list)
+	}
+	if len(failed) > 0 {
+		return groups, served, &discovery.ErrGroupDiscoveryFailed{Groups: failed}
+	}
+	return groups, served, nil
+}
+
+func (f *flakyDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	if f.down || f.failing[groupVersion] {
+		return nil, errors.New("the server is currently unable to handle the request")
+	}
+	return f.FakeDiscovery.ServerResourcesForGroupVersion(groupVersion)
+}
+
+func TestResourceMapperSync(t *testing.T) {
+	ctx := context.Background()
+	client := &flakyDiscovery{
+		FakeDiscovery: &fakediscovery.FakeDiscovery{
+			Fake: &clientgotesting.Fake{Resources: []*metav1.APIResourceList{{
+				GroupVersion: "north.america/v1",
+				APIResources: []metav1.APIResource{{Name: "ducks", Kind: "Duck"}},
+			}, {
+				GroupVersion: "south.america/v1",
+				APIResources: []metav1.APIResource{{Name: "llamas", Kind: "Llama"}},
+			}}},
+		},
+		failing: map[string]bool{"south.america/v1": true},
+	}
+	resynced := 0
+	s := NewResourceMapperSync(client, func() { resynced++ })
+	// Retries are run by the test.
+	s.backoff = wait.Backoff{Duration: time.Hour}
+
+	assertMapped := func(t *testing.T, rm collection.ResourceMapper, ducks, llamas bool) {
+		t.Helper()
+		if got := rm.ResourceExists("north.america/v1", "ducks"); got != ducks {
+			t.Errorf("ducks mapped = %t, want %t", got, ducks)
+		}
+		if got := rm.ResourceExists("south.america/v1", "llamas"); got != llamas {
+			t.Errorf("llamas mapped = %t, want %t", got, llamas)
+		}
+	}
+
+	// The resources of the group versions that were discovered are mapped.
+	s.Resync(ctx)
+	rm, err := s.Mapper()
+	assertMapped(t, rm, true, false)
+	want := "unable to discover south.america/v1: the server is currently unable to handle the request"
+	if err == nil || err.Error() != want {
+		t.Errorf("Mapper() error = %v, want %s", err, want)
+	}
+
+	// The failed group versions are retried until they are discovered.
+	s.retryFailed(ctx)
+	if _, err := s.Mapper(); err == nil {
+		t.Error("Mapper() error = nil, want an error while south.america/v1 is failing")
+	}
+	if resynced != 0 {
+		t.Errorf("resynced %d times, want 0", resynced)
+	}
+
+	client.failing = nil
+	s.retryFailed(ctx)
+	rm, err = s.Mapper()
+	assertMapped(t, rm, true, true)
+	if err != nil {
+		t.Error("Mapper() error =", err)
+	}
+	if resynced != 1 {
+		t.Errorf("resynced %d times, want 1", resynced)
+	}
+
+	// When discovery fails altogether the last mapper is kept.
+	client.down = true
+	s.Resync(ctx)
+	rm, err = s.Mapper()
+	assertMapped(t, rm, true, true)
+	if err == nil {
+		t.Error("Mapper() error = nil, want an error while discovery is down")
+	}
+
+	s.retryFailed(ctx)
+	if resynced != 1 {
+		t.Errorf("resynced %d times, want 1", resynced)
+	}
+
+	client.down = false
+	s.retryFailed(ctx)
+	if _, err := s.Mapper(); err != nil {
+		t.Error("Mapper() error =", err)
+	}
+	if resynced != 2 {
+		t.Errorf("resynced %d times, want 2", resynced)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"fmt"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// crdIndex indexes ClusterDuckTypes by the CRDs they may be affected by, see
+// crdIndexKeys for the keys of a CRD.
+const crdIndex = "crd"
+
+const (
+	// anyCRDKey indexes the duck types any CRD may affect, those discovering
+	// built-in kinds and those with selectors requiring no label.
+	anyCRDKey = "any"
+	// labelKeyPrefix indexes the duck types by the label their selectors
+	// require on CRDs.
+	labelKeyPrefix = "label:"
+	// refKeyPrefix indexes the duck types by the group kind and the group
+	// resource of their refs.
+	refKeyPrefix = "ref:"
+	// versionKeyPrefix indexes the duck types by the prefix of their duck
+	// version annotations.
+	versionKeyPrefix = "version:"
+)
+
+// indexByCRD implements cache.IndexFunc for crdIndex.
+func indexByCRD(obj interface{}) ([]string, error) {
+	dt, ok := obj.(*v1alpha1.ClusterDuckType)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterDuckType, got %T", obj)
+	}
+
+	keys := sets.NewString(versionKeyPrefix + duckVersionPrefix(dt))
+	if dt.Spec.DiscoverBuiltIns {
+		keys.Insert(anyCRDKey)
+	}
+	for _, st := range dt.Spec.Selectors {
+		keys.Insert(selectorKey(st))
+	}
+	for _, st := range dt.Spec.ExcludeSelectors {
+		keys.Insert(selectorKey(st))
+	}
+	return keys.Union(refKeys(dt)).List(), nil
+}
+
+// refKeys returns the keys of the group kinds and group resources of the
+// refs of the duck type.
+func refKeys(dt *v1alpha1.ClusterDuckType) sets.String {
+	keys := sets.NewString()
+	for _, dv := range dt.Spec.Versions {
+		for i := range dv.Refs {
+			ref := &dv.Refs[i]
+			gv, err := schema.ParseGroupVersion(ref.GroupVersion())
+			if err != nil {
+				continue
+			}
+			if ref.Kind != "" {
+				keys.Insert(refKeyPrefix + gv.WithKind(ref.Kind).GroupKind().String())
+			}
+			if ref.Resource != "" {
+				keys.Insert(refKeyPrefix + gv.WithResource(ref.Resource).GroupResource().String())
+			}
+		}
+	}
+	return keys
+}
+
+// selectorKey returns the key of the first label a CRD needs to match the
+// selector, or anyCRDKey if it needs none.
+func selectorKey(st v1alpha1.CustomResourceDefinitionSelector) string {
+	ls, err := labels.Parse(st.LabelSelector)
+	if err != nil {
+		return anyCRDKey
+	}
+	requirements, _ := ls.Requirements()
+	for _, r := range requirements {
+		switch r.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In, selection.Exists,
+			selection.GreaterThan, selection.LessThan:
+			return labelKeyPrefix + r.Key()
+		}
+	}
+	return anyCRDKey
+}
+
+// crdIndexKeys returns the keys of crdIndex under which the duck types the
+// CRD may affect are indexed.
+func crdIndexKeys(crd *apiextensionsv1.CustomResourceDefinition) []string {
+	keys := sets.NewString(anyCRDKey)
+	for k := range crd.Labels {
+		keys.Insert(labelKeyPrefix + k)
+	}
+	for k := range crd.Annotations {
+		if i := strings.LastIndex(k, "/"); i > 0 {
+			keys.Insert(versionKeyPrefix + k[:i])
+		}
+	}
+	for _, names := range []apiextensionsv1.CustomResourceDefinitionNames{crd.Spec.Names, crd.Status.AcceptedNames} {
+		if names.Kind != "" {
+			keys.Insert(refKeyPrefix + schema.GroupKind{Group: crd.Spec.Group, Kind: names.Kind}.String())
+		}
+		if names.Plural != "" {
+			keys.Insert(refKeyPrefix + schema.GroupResource{Group: crd.Spec.Group, Resource: names.Plural}.String())
+		}
+	}
+	return keys.List()
+}
+
+// affectedBy reports whether a change of the CRD may change the ducks of the
+// duck type: the CRD matches one of its selectors or refs, or has one of its
+// duck version annotations. Duck types discovering built-in kinds are
+// affected by every CRD, as the kinds served by CRDs are not built in.
+func affectedBy(dt *v1alpha1.ClusterDuckType, crd *apiextensionsv1.CustomResourceDefinition) bool {
+	if dt.Spec.DiscoverBuiltIns {
+		return true
+	}
+	for _, st := range dt.Spec.Selectors {
+		if selects(st, crd) {
+			return true
+		}
+	}
+	for _, st := range dt.Spec.ExcludeSelectors {
+		if selects(st, crd) {
+			return true
+		}
+	}
+	keys := sets.NewString(crdIndexKeys(crd)...)
+	return keys.Has(versionKeyPrefix+duckVersionPrefix(dt)) || keys.HasAny(refKeys(dt).List()...)
+}
+
+// selects reports whether the selector matches the CRD.
+func selects(st v1alpha1.CustomResourceDefinitionSelector, crd *apiextensionsv1.CustomResourceDefinition) bool {
+	ls, err := labels.Parse(st.LabelSelector)
+	if err != nil || !ls.Matches(labels.Set(crd.Labels)) {
+		return false
+	}
+	crds, err := FilterCRDs([]*apiextensionsv1.CustomResourceDefinition{crd}, st)
+	return err == nil && len(crds) > 0
+}
+
+// duckVersionPrefix returns the prefix of the annotations mapping the
+// versions of CRDs to the versions of the duck type.
+func duckVersionPrefix(dt *v1alpha1.ClusterDuckType) string {
+	return fmt.Sprintf("%s.%s", dt.Spec.Names.Plural, dt.Spec.Group)
+}
+
+// affectedDuckTypes returns the duck types of the indexer, indexed with crdIndex,
+// that a change of any of the CRDs may affect. Objects that are not CRDs,
+// or tombstones of CRDs, are ignored.
+func affectedDuckTypes(indexer cache.Indexer, objs []interface{}) ([]*v1alpha1.ClusterDuckType, error) {
+	seen := sets.NewString()
+	affected := make([]*v1alpha1.ClusterDuckType, 0)
+	for _, obj := range objs {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+		if !ok {
+			continue
+		}
+		for _, key := range crdIndexKeys(crd) {
+			candidates, err := indexer.ByIndex(crdIndex, key)
+			if err != nil {
+				return nil, err
+			}
+			for _, candidate := range candidates {
+				dt, ok := candidate.(*v1alpha1.ClusterDuckType)
+				if !ok || seen.Has(dt.Name) || !affectedBy(dt, crd) {
+					continue
+				}
+				seen.Insert(dt.Name)
+				affected = append(affected, dt)
+			}
+		}
+	}
+	return affected, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func duckType(name string, spec v1alpha1.ClusterDuckTypeSpec) *v1alpha1.ClusterDuckType {
+	spec.Group = "zoo.knative.dev"
+	return &v1alpha1.ClusterDuckType{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+}
+
+func labeledCRD(group, kind, plural string, labels, annotations map[string]string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        plural + "." + group,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: kind, Plural: plural},
+			Scope: apiextensionsv1.NamespaceScoped,
+		},
+	}
+}
+
+func TestAffectedDuckTypes(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{crdIndex: indexByCRD})
+	for _, dt := range []*v1alpha1.ClusterDuckType{
+		duckType("swimmers.zoo.knative.dev", v1alpha1.ClusterDuckTypeSpec{
+			Names:     v1alpha1.DuckTypeNames{Plural: "swimmers"},
+			Selectors: []v1alpha1.CustomResourceDefinitionSelector{{LabelSelector: "zoo.knative.dev/swims=true"}},
+		}),
+		duckType("hoppers.zoo.knative.dev", v1alpha1.ClusterDuckTypeSpec{
+			Names: v1alpha1.DuckTypeNames{Plural: "hoppers"},
+			Selectors: []v1alpha1.CustomResourceDefinitionSelector{{
+				LabelSelector: "zoo.knative.dev/hops",
+				Groups:        []string{"australia"},
+			}},
+		}),
+		duckType("walkers.zoo.knative.dev", v1alpha1.ClusterDuckTypeSpec{
+			Names:     v1alpha1.DuckTypeNames{Plural: "walkers"},
+			Selectors: []v1alpha1.CustomResourceDefinitionSelector{{LabelSelector: "!zoo.knative.dev/swims"}},
+		}),
+		duckType("waders.zoo.knative.dev", v1alpha1.ClusterDuckTypeSpec{
+			Names: v1alpha1.DuckTypeNames{Plural: "waders"},
+			Versions: []v1alpha1.DuckVersion{{
+				Name: "v1",
+				Refs: []v1alpha1.ResourceRef{{APIVersion: "africa/v1", Kind: "Flamingo"}, {Group: "asia", Version: "v1", Resource: "herons"}},
+			}},
+		}),
+		duckType("climbers.zoo.knative.dev", v1alpha1.ClusterDuckTypeSpec{
+			Names: v1alpha1.DuckTypeNames{Plural: "climbers"},
+		}),
+		duckType("builtins.zoo.knative.dev", v1alpha1.ClusterDuckTypeSpec{
+			Names:            v1alpha1.DuckTypeNames{Plural: "builtins"},
+			DiscoverBuiltIns: true,
+		}),
+	} {
+		if err := indexer.Add(dt); err != nil {
+			t.Fatal("Add() =", err)
+		}
+	}
+
+	tests := map[string]struct {
+		crds []interface{}
+		want []string
+	}{
+		"matching label": {
+			crds: []interface{}{labeledCRD("north.america", "Duck", "ducks", map[string]string{"zoo.knative.dev/swims": "true"}, nil)},
+			want: []string{"builtins.zoo.knative.dev", "swimmers.zoo.knative.dev"},
+		},
+		"label value mismatch": {
+			crds: []interface{}{labeledCRD("north.america", "Duck", "ducks", map[string]string{"zoo.knative.dev/swims": "false"}, nil)},
+			want: []string{"builtins.zoo.knative.dev"},
+		},
+		"label and group": {
+			crds: []interface{}{
+				labeledCRD("australia", "Kangaroo", "kangaroos", map[string]string{"zoo.knative.dev/hops": "true"}, nil),
+				labeledCRD("africa", "Frog", "frogs", map[string]string{"zoo.knative.dev/hops": "true"}, nil),
+			},
+			want: []string{"builtins.zoo.knative.dev", "hoppers.zoo.knative.dev", "walkers.zoo.knative.dev"},
+		},
+		"before and after a change": {
+			crds: []interface{}{
+				labeledCRD("north.america", "Duck", "ducks", map[string]string{"zoo.knative.dev/swims": "true"}, nil),
+				labeledCRD("north.america", "Duck", "ducks", nil, nil),
+			},
+			want: []string{"builtins.zoo.knative.dev", "swimmers.zoo.knative.dev", "walkers.zoo.knative.dev"},
+		},
+		"ref kind": {
+			crds: []interface{}{labeledCRD("africa", "Flamingo", "flamingos", map[string]string{"zoo.knative.dev/swims": "false"}, nil)},
+			want: []string{"builtins.zoo.knative.dev", "waders.zoo.knative.dev"},
+		},
+		"ref resource of a deleted CRD": {
+			crds: []interface{}{cache.DeletedFinalStateUnknown{
+				Key: "herons.asia",
+				Obj: labeledCRD("asia", "Heron", "herons", map[string]string{"zoo.knative.dev/swims": "false"}, nil),
+			}},
+			want: []string{"builtins.zoo.knative.dev", "waders.zoo.knative.dev"},
+		},
+		"version annotation": {
+			crds: []interface{}{labeledCRD("central.america", "Monkey", "monkeys", map[string]string{"zoo.knative.dev/swims": "false"}, map[string]string{
+				"climbers.zoo.knative.dev/v1": "v1",
+			})},
+			want: []string{"builtins.zoo.knative.dev", "climbers.zoo.knative.dev"},
+		},
+		"not a CRD": {
+			crds: []interface{}{"ducks.north.america"},
+			want: []string{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dts, err := affectedDuckTypes(indexer, tc.crds)
+			if err != nil {
+				t.Fatal("affectedDuckTypes() =", err)
+			}
+			got := make([]string, 0, len(dts))
+			for _, dt := range dts {
+				got = append(got, dt.Name)
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("affected (-want, +got):", diff)
+			}
+		})
+	}
+}
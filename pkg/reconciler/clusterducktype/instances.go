@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	"knative.dev/discovery/pkg/inventory"
+)
+
+// maxWorstOffenders caps the instances listed in ReadinessRollup.WorstOffenders.
+const maxWorstOffenders = 10
+
+// listFunc reports whether the instances of a resource can be listed and
+// watched in all namespaces.
+type listFunc func(gvr schema.GroupVersionResource) (bool, error)
+
+// takeInventory tracks the resources of the ducks in the inventory on behalf
+// of owner, counts the instances of each kind of duck and sums up their
+// readiness. A kind is counted once, using its preferred version. Kinds that
+// the aggregating ClusterRole does not grant access to, or that canList
+// rejects, are not counted and are returned as uncounted. Kinds whose
+// instances are not known yet are returned as unsynced.
+func takeInventory(inv inventory.Inventory, owner string, ducks map[string][]v1alpha1.ResourceMeta, canList listFunc) ([]v1alpha1.InstanceCount, *v1alpha1.ReadinessRollup, []string, []string, error) {
+	versions := make([]string, 0, len(ducks))
+	for version := range ducks {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	all := make([]v1alpha1.ResourceMeta, 0)
+	for _, version := range versions {
+		all = append(all, ducks[version]...)
+	}
+
+	metas := make([]v1alpha1.ResourceMeta, 0)
+	gvrs := make([]schema.GroupVersionResource, 0)
+	var uncounted []string
+	for _, meta := range v1alpha1.PreferredVersions(all) {
+		if meta.Resource == "" {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(meta.APIVersion)
+		if err != nil {
+			continue
+		}
+		gvr := gv.WithResource(meta.Resource)
+		if !meta.AccessibleViaClusterRole {
+			uncounted = append(uncounted, fmt.Sprintf("%s: not granted by the aggregating ClusterRole", gvr.GroupResource()))
+			continue
+		}
+		allowed, err := canList(gvr)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if !allowed {
+			uncounted = append(uncounted, fmt.Sprintf("%s: the controller cannot list and watch it", gvr.GroupResource()))
+			continue
+		}
+		metas = append(metas, meta)
+		gvrs = append(gvrs, gvr)
+	}
+
+	inv.Track(owner, gvrs)
+
+	var counts []v1alpha1.InstanceCount
+	var rollup *v1alpha1.ReadinessRollup
+	var unsynced []string
+	for i, gvr := range gvrs {
+		instances, synced := inv.List(gvr)
+		if !synced {
+			unsynced = append(unsynced, gvr.GroupResource().String())
+			continue
+		}
+		ic := v1alpha1.InstanceCount{
+			APIVersion: metas[i].APIVersion,
+			Kind:       metas[i].Kind,
+			Count:      len(instances),
+		}
+		if rollup == nil {
+			rollup = &v1alpha1.ReadinessRollup{}
+		}
+		for _, instance := range instances {
+			if ns := instance.GetNamespace(); ns != "" {
+				if ic.Namespaces == nil {
+					ic.Namespaces = make(map[string]int)
+				}
+				ic.Namespaces[ns]++
+			}
+
+			status, cond := inventory.Readiness(instance)
+			switch status {
+			case corev1.ConditionTrue:
+				rollup.Ready++
+				continue
+			case corev1.ConditionFalse:
+				rollup.NotReady++
+			default:
+				rollup.Unknown++
+			}
+			nri := v1alpha1.NotReadyInstance{
+				APIVersion: metas[i].APIVersion,
+				Kind:       metas[i].Kind,
+				Namespace:  instance.GetNamespace(),
+				Name:       instance.GetName(),
+				Status:     status,
+			}
+			if cond != nil {
+				nri.Reason = cond.Reason
+				nri.Message = cond.Message
+				nri.LastTransitionTime = cond.LastTransitionTime
+			}
+			rollup.WorstOffenders = append(rollup.WorstOffenders, nri)
+		}
+		counts = append(counts, ic)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].APIVersion != counts[j].APIVersion {
+			return counts[i].APIVersion < counts[j].APIVersion
+		}
+		return counts[i].Kind < counts[j].Kind
+	})
+	if rollup != nil {
+		sort.Sort(byWorstOffender(rollup.WorstOffenders))
+		if len(rollup.WorstOffenders) > maxWorstOffenders {
+			rollup.WorstOffenders = rollup.WorstOffenders[:maxWorstOffenders]
+		}
+	}
+	return counts, rollup, uncounted, unsynced, nil
+}
+
+// canListAll asks the API server whether the controller can list and watch
+// the resource in all namespaces.
+func canListAll(ctx context.Context, client kubernetes.Interface, gvr schema.GroupVersionResource) (bool, error) {
+	for _, verb := range []string{"list", "watch"} {
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    gvr.Group,
+					Version:  gvr.Version,
+					Resource: gvr.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to review access to %s: %w", gvr.GroupResource(), err)
+		}
+		if !review.Status.Allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// byWorstOffender orders instances that are not ready before instances whose
+// readiness is unknown, and then the longest standing first.
+type byWorstOffender []v1alpha1.NotReadyInstance
+
+func (a byWorstOffender) Len() int      { return len(a) }
+func (a byWorstOffender) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a byWorstOffender) Less(i, j int) bool {
+	if a[i].Status != a[j].Status {
+		return a[i].Status == corev1.ConditionFalse
+	}
+	ti, tj := a[i].LastTransitionTime.Inner, a[j].LastTransitionTime.Inner
+	if !ti.Equal(&tj) {
+		return ti.Before(&tj)
+	}
+	if a[i].Namespace != a[j].Namespace {
+		return a[i].Namespace < a[j].Namespace
+	}
+	if a[i].Kind != a[j].Kind {
+		return a[i].Kind < a[j].Kind
+	}
+	return a[i].Name < a[j].Name
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// fakeInventory serves static instances for the resources it knows.
+type fakeInventory struct {
+	instances map[schema.GroupVersionResource][]*unstructured.Unstructured
+	tracked   map[string][]schema.GroupVersionResource
+}
+
+func (f *fakeInventory) Track(owner string, gvrs []schema.GroupVersionResource) {
+	f.tracked[owner] = gvrs
+}
+
+func (f *fakeInventory) Forget(owner string) {
+	delete(f.tracked, owner)
+}
+
+func (f *fakeInventory) List(gvr schema.GroupVersionResource) ([]*unstructured.Unstructured, bool) {
+	instances, found := f.instances[gvr]
+	return instances, found
+}
+
+func instance(namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func withReady(u *unstructured.Unstructured, status, reason, ltt string) *unstructured.Unstructured {
+	_ = unstructured.SetNestedSlice(u.Object, []interface{}{
+		map[string]interface{}{
+			"type":               "Ready",
+			"status":             status,
+			"reason":             reason,
+			"lastTransitionTime": ltt,
+		},
+	}, "status", "conditions")
+	return u
+}
+
+func withGenerations(u *unstructured.Unstructured, generation, observed int64) *unstructured.Unstructured {
+	u.SetGeneration(generation)
+	_ = unstructured.SetNestedField(u.Object, observed, "status", "observedGeneration")
+	return u
+}
+
+func ltt(t string) apis.VolatileTime {
+	parsed, _ := time.Parse(time.RFC3339, t)
+	return apis.VolatileTime{Inner: metav1.NewTime(parsed)}
+}
+
+func TestTakeInventory(t *testing.T) {
+	ducks := schema.GroupVersionResource{Group: "north.america", Version: "v1alpha2", Resource: "ducks"}
+	gilamonsters := schema.GroupVersionResource{Group: "north.america", Version: "v2", Resource: "gilamonsters"}
+	platypi := schema.GroupVersionResource{Group: "australia", Version: "v1", Resource: "platypi"}
+	echidnas := schema.GroupVersionResource{Group: "australia", Version: "v1", Resource: "echidnas"}
+
+	inv := &fakeInventory{
+		instances: map[schema.GroupVersionResource][]*unstructured.Unstructured{
+			ducks: {
+				withReady(instance("pond", "mallard"), "True", "", "2022-01-01T00:00:00Z"),
+				withReady(instance("pond", "teal"), "False", "Molting", "2022-01-02T00:00:00Z"),
+				withReady(instance("lake", "eider"), "False", "Migrating", "2022-01-01T00:00:00Z"),
+			},
+			gilamonsters: {
+				instance("", "gila"),
+				withGenerations(withReady(instance("", "heloderma"), "True", "", "2022-01-01T00:00:00Z"), 2, 1),
+			},
+			// Synced, without instances.
+			echidnas: {},
+		},
+		tracked: map[string][]schema.GroupVersionResource{},
+	}
+
+	kangaroos := schema.GroupVersionResource{Group: "australia", Version: "v1", Resource: "kangaroos"}
+	canList := func(gvr schema.GroupVersionResource) (bool, error) {
+		return gvr != kangaroos, nil
+	}
+
+	counts, readiness, uncounted, unsynced, err := takeInventory(inv, "swimmers.zoo.knative.dev", map[string][]v1alpha1.ResourceMeta{
+		"v1": {{
+			APIVersion: "north.america/v1alpha2",
+			Kind:       "Duck",
+			Resource:   "ducks",
+
+			AccessibleViaClusterRole: true,
+		}, {
+			APIVersion: "north.america/v1beta1",
+			Kind:       "Duck",
+			Resource:   "ducks",
+
+			AccessibleViaClusterRole: true,
+		}},
+		"v2": {{
+			APIVersion: "north.america/v2",
+			Kind:       "GilaMonster",
+			Resource:   "gilamonsters",
+
+			AccessibleViaClusterRole: true,
+		}},
+		"v3": {{
+			APIVersion: "australia/v1",
+			Kind:       "Platypus",
+			Resource:   "platypi",
+
+			AccessibleViaClusterRole: true,
+		}, {
+			APIVersion: "australia/v1",
+			Kind:       "Echidna",
+			Resource:   "echidnas",
+
+			AccessibleViaClusterRole: true,
+		}, {
+			APIVersion: "australia/v1",
+			Kind:       "Wombat",
+		}, {
+			APIVersion: "australia/v1",
+			Kind:       "Kangaroo",
+			Resource:   "kangaroos",
+
+			AccessibleViaClusterRole: true,
+		}},
+		"v4": {{
+			APIVersion: "europe/v1",
+			Kind:       "Swan",
+			Resource:   "swans",
+		}},
+	}, canList)
+	if err != nil {
+		t.Fatal("takeInventory() =", err)
+	}
+
+	want := []v1alpha1.InstanceCount{{
+		APIVersion: "australia/v1",
+		Kind:       "Echidna",
+		Count:      0,
+	}, {
+		APIVersion: "north.america/v1alpha2",
+		Kind:       "Duck",
+		Count:      3,
+		Namespaces: map[string]int{"pond": 2, "lake": 1},
+	}, {
+		APIVersion: "north.america/v2",
+		Kind:       "GilaMonster",
+		Count:      2,
+	}}
+	if diff := cmp.Diff(want, counts); diff != "" {
+		t.Error("counts (-want, +got):", diff)
+	}
+
+	wantReadiness := &v1alpha1.ReadinessRollup{
+		Ready:    1,
+		NotReady: 2,
+		Unknown:  2,
+		WorstOffenders: []v1alpha1.NotReadyInstance{{
+			APIVersion:         "north.america/v1alpha2",
+			Kind:               "Duck",
+			Namespace:          "lake",
+			Name:               "eider",
+			Status:             corev1.ConditionFalse,
+			Reason:             "Migrating",
+			LastTransitionTime: ltt("2022-01-01T00:00:00Z"),
+		}, {
+			APIVersion:         "north.america/v1alpha2",
+			Kind:               "Duck",
+			Namespace:          "pond",
+			Name:               "teal",
+			Status:             corev1.ConditionFalse,
+			Reason:             "Molting",
+			LastTransitionTime: ltt("2022-01-02T00:00:00Z"),
+		}, {
+			APIVersion: "north.america/v2",
+			Kind:       "GilaMonster",
+			Name:       "gila",
+			Status:     corev1.ConditionUnknown,
+		}, {
+			APIVersion:         "north.america/v2",
+			Kind:               "GilaMonster",
+			Name:               "heloderma",
+			Status:             corev1.ConditionUnknown,
+			LastTransitionTime: ltt("2022-01-01T00:00:00Z"),
+		}},
+	}
+	if diff := cmp.Diff(wantReadiness, readiness); diff != "" {
+		t.Error("readiness (-want, +got):", diff)
+	}
+
+	wantUncounted := []string{
+		"kangaroos.australia: the controller cannot list and watch it",
+		"swans.europe: not granted by the aggregating ClusterRole",
+	}
+	if diff := cmp.Diff(wantUncounted, uncounted); diff != "" {
+		t.Error("uncounted (-want, +got):", diff)
+	}
+
+	// Platypi are not synced yet, they are not counted as none.
+	if diff := cmp.Diff([]string{"platypi.australia"}, unsynced); diff != "" {
+		t.Error("unsynced (-want, +got):", diff)
+	}
+
+	// Each kind is tracked once, platypi are tracked but not synced yet.
+	wantTracked := []schema.GroupVersionResource{ducks, gilamonsters, platypi, echidnas}
+	if diff := cmp.Diff(wantTracked, inv.tracked["swimmers.zoo.knative.dev"]); diff != "" {
+		t.Error("tracked (-want, +got):", diff)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/kmeta"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// ManagedRoleName returns the name of the ClusterRole maintained for the
+// ducks of the duck type.
+func ManagedRoleName(dt *v1alpha1.ClusterDuckType) string {
+	return kmeta.ChildName(dt.Name, "-ducks")
+}
+
+// MakeManagedRole creates the ClusterRole granting the verbs of the managed
+// role on every resource of the ducks, with one rule per API group.
+func MakeManagedRole(dt *v1alpha1.ClusterDuckType, ducks map[string][]v1alpha1.ResourceMeta) *rbacv1.ClusterRole {
+	managed := dt.Spec.Role.Managed
+
+	resources := make(map[string]sets.String)
+	for _, metas := range ducks {
+		for _, meta := range metas {
+			if meta.Resource == "" {
+				continue
+			}
+			if _, found := resources[meta.Group()]; !found {
+				resources[meta.Group()] = sets.NewString()
+			}
+			resources[meta.Group()].Insert(meta.Resource)
+		}
+	}
+	groups := make([]string, 0, len(resources))
+	for group := range resources {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	rules := make([]rbacv1.PolicyRule, 0, len(groups))
+	for _, group := range groups {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: resources[group].List(),
+			Verbs:     managed.Verbs,
+		})
+	}
+
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ManagedRoleName(dt),
+			Labels:          map[string]string{managed.AggregationLabel(): "true"},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(dt)},
+		},
+		Rules: rules,
+	}
+}
+
+// reconcileManagedRole creates or updates the ClusterRole managed for the
+// ducks of the duck type, and deletes it once the duck type no longer asks
+// for one. A ClusterRole of the same name not controlled by the duck type is
+// left alone.
+func (r *Reconciler) reconcileManagedRole(ctx context.Context, dt *v1alpha1.ClusterDuckType, ducks map[string][]v1alpha1.ResourceMeta) error {
+	name := ManagedRoleName(dt)
+	existing, err := r.clusterRoleLister.Get(name)
+	if apierrs.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return err
+	}
+	wanted := dt.Spec.Role != nil && dt.Spec.Role.Managed != nil
+
+	if existing != nil && !metav1.IsControlledBy(existing, dt) {
+		if wanted {
+			return fmt.Errorf("ClusterRole %q is not owned by %s", name, dt.Name)
+		}
+		return nil
+	}
+
+	switch {
+	case !wanted && existing == nil:
+		return nil
+
+	case !wanted:
+		return r.client.RbacV1().ClusterRoles().Delete(ctx, name, metav1.DeleteOptions{})
+
+	case existing == nil:
+		_, err = r.client.RbacV1().ClusterRoles().Create(ctx, MakeManagedRole(dt, ducks), metav1.CreateOptions{})
+		return missingGrant(dt, err)
+	}
+
+	want := MakeManagedRole(dt, ducks)
+	if equality.Semantic.DeepEqual(existing.Rules, want.Rules) && equality.Semantic.DeepEqual(existing.Labels, want.Labels) {
+		return nil
+	}
+	update := existing.DeepCopy()
+	update.Labels = want.Labels
+	update.Rules = want.Rules
+	_, err = r.client.RbacV1().ClusterRoles().Update(ctx, update, metav1.UpdateOptions{})
+	return missingGrant(dt, err)
+}
+
+// missingGrant explains a Forbidden error writing the managed ClusterRole.
+// The controller may not escalate, so it can only grant the verbs it holds on
+// the ducks.
+func missingGrant(dt *v1alpha1.ClusterDuckType, err error) error {
+	if !apierrs.IsForbidden(err) {
+		return err
+	}
+	return fmt.Errorf("the controller needs %s on the ducks to grant them: %w", strings.Join(dt.Spec.Role.Managed.Verbs, ", "), err)
+}
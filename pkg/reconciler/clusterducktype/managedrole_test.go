@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	. "knative.dev/discovery/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestReconcileManagedRoleForbidden(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "clusterroles", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrs.NewForbidden(schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+			"paddlers.zoo.knative.dev-ducks", nil)
+	})
+	listers := NewListers(nil)
+	r := &Reconciler{
+		client:            client,
+		crdLister:         listers.GetCustomResourceDefinitionLister(),
+		clusterRoleLister: listers.GetClusterRoleLister(),
+		mappers: NewResourceMapperSync(&fakediscovery.FakeDiscovery{
+			Fake: &clientgotesting.Fake{Resources: apiGroups},
+		}, nil),
+	}
+	r.mappers.Resync(ctx)
+
+	dt := &v1alpha1.ClusterDuckType{
+		ObjectMeta: metav1.ObjectMeta{Name: "paddlers.zoo.knative.dev"},
+		Spec: v1alpha1.ClusterDuckTypeSpec{
+			Group:    "zoo.knative.dev",
+			Names:    v1alpha1.DuckTypeNames{Name: "Paddler", Plural: "paddlers", Singular: "paddler"},
+			Versions: []v1alpha1.DuckVersion{{Name: "v1"}},
+			Role: &v1alpha1.Role{
+				Managed: &v1alpha1.ManagedRole{AggregateTo: "zoo-keeper", Verbs: []string{"get", "list", "watch"}},
+			},
+		},
+	}
+	dt.Status.InitializeConditions()
+
+	if err := r.ReconcileKind(ctx, dt); !apierrs.IsForbidden(err) {
+		t.Errorf("ReconcileKind() = %v, want Forbidden", err)
+	}
+	cond := dt.Status.GetCondition(v1alpha1.DuckTypeConditionRoleResolved)
+	if cond == nil || cond.Status != "False" || cond.Reason != "ManagedRoleFailed" {
+		t.Fatalf("RoleResolved = %+v, want False with reason ManagedRoleFailed", cond)
+	}
+	if !strings.Contains(cond.Message, "the controller needs get, list, watch on the ducks") {
+		t.Errorf("RoleResolved message = %q, want the missing grant", cond.Message)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"knative.dev/discovery/pkg/collection"
+)
+
+// openAPISource lists the kinds served by the API server with their schemas.
+type openAPISource interface {
+	Kinds(ctx context.Context) ([]collection.OpenAPIKind, error)
+}
+
+// openAPIKinds reads the kinds served by the API server from its OpenAPI v3
+// documents. The kinds are read again by Refresh, which is called with each
+// discovery of the resources served by the cluster, so that reconciles do not
+// request the documents. Refresh only reads them if they were requested since
+// the last refresh, otherwise the next call to Kinds does. The documents are
+// cached by their URL, which holds a hash of the document.
+type openAPIKinds struct {
+	client rest.Interface
+
+	mu    sync.Mutex
+	cache map[string][]collection.OpenAPIKind
+	kinds []collection.OpenAPIKind
+	// err is the error of the last refresh, the kinds are refreshed again by
+	// the next call to Kinds if it is set.
+	err error
+	// wanted is set when the kinds are requested, and cleared by Refresh.
+	wanted bool
+	// stale is set when Refresh skipped the kinds because they were not
+	// wanted, the next call to Kinds refreshes them.
+	stale bool
+}
+
+var _ openAPISource = (*openAPIKinds)(nil)
+
+// openAPIIndex is the list of OpenAPI v3 documents served by the API server.
+type openAPIIndex struct {
+	Paths map[string]struct {
+		ServerRelativeURL string `json:"serverRelativeURL"`
+	} `json:"paths"`
+}
+
+// Kinds implements openAPISource. It returns the kinds read by the last
+// refresh, or refreshes them if it failed or was skipped.
+func (o *openAPIKinds) Kinds(ctx context.Context) ([]collection.OpenAPIKind, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.wanted = true
+	if o.kinds == nil || o.err != nil || o.stale {
+		o.refresh(ctx)
+	}
+	return o.kinds, o.err
+}
+
+// Refresh reads the OpenAPI v3 index again, and the documents that changed,
+// if the kinds were requested since the last refresh.
+func (o *openAPIKinds) Refresh(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.wanted {
+		o.stale = true
+		return nil
+	}
+	o.wanted = false
+	o.refresh(ctx)
+	return o.err
+}
+
+// refresh is Refresh, o.mu must be held. The kinds of the last successful
+// refresh are kept if it fails.
+func (o *openAPIKinds) refresh(ctx context.Context) {
+	kinds, err := o.read(ctx)
+	o.err = err
+	o.stale = false
+	if err == nil {
+		o.kinds = kinds
+	}
+}
+
+// read reads the kinds of the documents listed by the OpenAPI v3 index, o.mu
+// must be held.
+func (o *openAPIKinds) read(ctx context.Context) ([]collection.OpenAPIKind, error) {
+	raw, err := o.client.Get().AbsPath("/openapi/v3").Do(ctx).Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the OpenAPI v3 index: %w", err)
+	}
+	index := &openAPIIndex{}
+	if err := json.Unmarshal(raw, index); err != nil {
+		return nil, fmt.Errorf("failed to read the OpenAPI v3 index: %w", err)
+	}
+
+	paths := make([]string, 0, len(index.Paths))
+	for path := range index.Paths {
+		// Only the documents of group versions serve kinds.
+		if strings.HasPrefix(path, "api/") || (strings.HasPrefix(path, "apis/") && strings.Count(path, "/") == 2) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	cache := make(map[string][]collection.OpenAPIKind, len(paths))
+	kinds := make([]collection.OpenAPIKind, 0)
+	for _, path := range paths {
+		url := index.Paths[path].ServerRelativeURL
+		docKinds, found := o.cache[url]
+		if !found {
+			raw, err := o.client.Get().RequestURI(url).Do(ctx).Raw()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the OpenAPI v3 document of %s: %w", path, err)
+			}
+			if docKinds, err = collection.ParseOpenAPIKinds(raw); err != nil {
+				return nil, fmt.Errorf("failed to read the OpenAPI v3 document of %s: %w", path, err)
+			}
+		}
+		cache[url] = docKinds
+		kinds = append(kinds, docKinds...)
+	}
+	o.cache = cache
+	return kinds, nil
+}
+
+// builtInKinds returns the kinds that are not served by one of the CRDs, which
+// are discovered through selectors.
+func builtInKinds(kinds []collection.OpenAPIKind, crds []*apiextensionsv1.CustomResourceDefinition) []collection.OpenAPIKind {
+	custom := make(map[schema.GroupKind]bool, len(crds))
+	for _, crd := range crds {
+		custom[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = true
+		custom[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Status.AcceptedNames.Kind}] = true
+	}
+	builtIns := make([]collection.OpenAPIKind, 0, len(kinds))
+	for _, k := range kinds {
+		if !custom[k.GroupVersionKind.GroupKind()] {
+			builtIns = append(builtIns, k)
+		}
+	}
+	return builtIns
+}
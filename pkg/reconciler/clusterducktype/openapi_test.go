@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestOpenAPIKinds(t *testing.T) {
+	doc, err := ioutil.ReadFile("testdata/openapi/apis_apps_v1.json")
+	if err != nil {
+		t.Fatal("failed to read the document:", err)
+	}
+	var indexes, docs, failing int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi/v3":
+			atomic.AddInt32(&indexes, 1)
+			if atomic.LoadInt32(&failing) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"paths": {"apis/apps/v1": {"serverRelativeURL": "/openapi/v3/apis/apps/v1?hash=1"}}}`))
+		case "/openapi/v3/apis/apps/v1":
+			atomic.AddInt32(&docs, 1)
+			w.Write(doc)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal("failed to create the client:", err)
+	}
+	o := &openAPIKinds{client: client.Discovery().RESTClient()}
+	ctx := context.Background()
+
+	// The kinds are not read until they are requested.
+	if err := o.Refresh(ctx); err != nil {
+		t.Fatal("Refresh() =", err)
+	}
+	if got := atomic.LoadInt32(&indexes); got != 0 {
+		t.Errorf("index read %d times before the kinds were requested, want never", got)
+	}
+
+	// The kinds are read once, then served from the cache.
+	for i := 0; i < 3; i++ {
+		kinds, err := o.Kinds(ctx)
+		if err != nil {
+			t.Fatal("Kinds() =", err)
+		}
+		if len(kinds) == 0 {
+			t.Fatal("Kinds() found no kinds")
+		}
+	}
+	if got, gotDocs := atomic.LoadInt32(&indexes), atomic.LoadInt32(&docs); got != 1 || gotDocs != 1 {
+		t.Errorf("index read %d times and document %d times, want once", got, gotDocs)
+	}
+
+	// A refresh reads the index again, the document did not change.
+	if err := o.Refresh(ctx); err != nil {
+		t.Fatal("Refresh() =", err)
+	}
+	if got, gotDocs := atomic.LoadInt32(&indexes), atomic.LoadInt32(&docs); got != 2 || gotDocs != 1 {
+		t.Errorf("index read %d times and document %d times, want twice and once", got, gotDocs)
+	}
+
+	// A refresh without a request since the last one is skipped, the next
+	// call to Kinds reads the index again.
+	if err := o.Refresh(ctx); err != nil {
+		t.Fatal("Refresh() =", err)
+	}
+	if got := atomic.LoadInt32(&indexes); got != 2 {
+		t.Errorf("index read %d times, want twice", got)
+	}
+	if _, err := o.Kinds(ctx); err != nil {
+		t.Fatal("Kinds() =", err)
+	}
+	if got, gotDocs := atomic.LoadInt32(&indexes), atomic.LoadInt32(&docs); got != 3 || gotDocs != 1 {
+		t.Errorf("index read %d times and document %d times, want 3 times and once", got, gotDocs)
+	}
+
+	// A failed refresh keeps the kinds, and the next call to Kinds retries.
+	atomic.StoreInt32(&failing, 1)
+	if err := o.Refresh(ctx); err == nil {
+		t.Error("expected Refresh() to fail")
+	}
+	if kinds, err := o.Kinds(ctx); err == nil || len(kinds) == 0 {
+		t.Errorf("Kinds() = %d kinds, %v, want the kinds of the last refresh and an error", len(kinds), err)
+	}
+	atomic.StoreInt32(&failing, 0)
+	if _, err := o.Kinds(ctx); err != nil {
+		t.Error("Kinds() =", err)
+	}
+	if got := atomic.LoadInt32(&indexes); got != 6 {
+		t.Errorf("index read %d times, want 6", got)
+	}
+}
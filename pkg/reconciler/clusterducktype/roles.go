@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// ambiguousRoleError is returned when several ClusterRoles aggregate the
+// ducks of a duck type.
+type ambiguousRoleError struct {
+	names []string
+}
+
+func (e *ambiguousRoleError) Error() string {
+	return fmt.Sprintf("several ClusterRoles aggregate the ducks: %s", strings.Join(e.names, ", "))
+}
+
+// getClusterRoleAggregating returns the ClusterRole with an AggregationRule
+// selecting the labels of the ducks matched by the selectors, or nil if there
+// is none. It is an *ambiguousRoleError if several ClusterRoles do.
+func getClusterRoleAggregating(selectors []v1alpha1.CustomResourceDefinitionSelector, clusterRoles []rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+	candidates := make([]labels.Set, 0)
+	for _, st := range selectors {
+		candidates = append(candidates, labelSetsFor(st.LabelSelector)...)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var matched []*rbacv1.ClusterRole
+	for i := range clusterRoles {
+		if aggregatesAny(clusterRoles[i].AggregationRule, candidates) {
+			matched = append(matched, &clusterRoles[i])
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matched[0], nil
+	default:
+		names := make([]string, 0, len(matched))
+		for _, cr := range matched {
+			names = append(names, cr.Name)
+		}
+		sort.Strings(names)
+		return nil, &ambiguousRoleError{names: names}
+	}
+}
+
+// aggregatesAny reports whether any ClusterRoleSelector of the rule matches
+// any of the label sets. Empty selectors, which aggregate every ClusterRole,
+// are not specific to ducks and are ignored.
+func aggregatesAny(rule *rbacv1.AggregationRule, candidates []labels.Set) bool {
+	if rule == nil {
+		return false
+	}
+	for i := range rule.ClusterRoleSelectors {
+		sel, err := metav1.LabelSelectorAsSelector(&rule.ClusterRoleSelectors[i])
+		if err != nil || sel.Empty() {
+			continue
+		}
+		for _, set := range candidates {
+			if sel.Matches(set) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// labelSetsFor returns the label sets a duck matched by the label selector
+// carries, built from the equality and set based requirements of the
+// selector. Keys that only have to exist get the value "true", the value
+// ducks are conventionally labeled with. Label sets that do not satisfy the
+// selector as a whole, say because of a != requirement, are dropped.
+func labelSetsFor(labelSelector string) []labels.Set {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil
+	}
+	reqs, _ := selector.Requirements()
+
+	sets := []labels.Set{{}}
+	for _, req := range reqs {
+		var values []string
+		switch req.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+			values = req.Values().List()
+		case selection.Exists:
+			values = []string{"true"}
+		default:
+			continue
+		}
+
+		next := make([]labels.Set, 0, len(sets)*len(values))
+		for _, set := range sets {
+			for _, value := range values {
+				candidate := labels.Set{req.Key(): value}
+				for k, v := range set {
+					candidate[k] = v
+				}
+				next = append(next, candidate)
+			}
+		}
+		sets = next
+	}
+
+	valid := make([]labels.Set, 0, len(sets))
+	for _, set := range sets {
+		if len(set) > 0 && selector.Matches(set) {
+			valid = append(valid, set)
+		}
+	}
+	return valid
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func aggregatingRole(name string, selectors ...metav1.LabelSelector) rbacv1.ClusterRole {
+	return rbacv1.ClusterRole{
+		ObjectMeta:      metav1.ObjectMeta{Name: name},
+		AggregationRule: &rbacv1.AggregationRule{ClusterRoleSelectors: selectors},
+	}
+}
+
+func TestGetClusterRoleAggregating(t *testing.T) {
+	ears := aggregatingRole("ears-resolver", metav1.LabelSelector{
+		MatchLabels: map[string]string{"zoo.knative.dev/ears": "true"},
+	})
+	notSwimming := aggregatingRole("land-resolver", metav1.LabelSelector{
+		MatchLabels: map[string]string{"zoo.knative.dev/swims": "false"},
+	})
+	hops := aggregatingRole("hops-resolver", metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "zoo.knative.dev/hops",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{"true", "yes"},
+		}},
+	})
+	jumps := aggregatingRole("jumps-resolver", metav1.LabelSelector{
+		MatchLabels: map[string]string{"zoo.knative.dev/hops": "true"},
+	})
+	everything := aggregatingRole("everything", metav1.LabelSelector{})
+	plain := rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "plain"}}
+	roles := []rbacv1.ClusterRole{plain, everything, ears, notSwimming, hops, jumps}
+
+	tests := map[string]struct {
+		selectors []string
+		want      string
+		ambiguous []string
+	}{
+		"match labels": {
+			selectors: []string{"zoo.knative.dev/ears=true"},
+			want:      "ears-resolver",
+		},
+		"exists": {
+			selectors: []string{"zoo.knative.dev/ears"},
+			want:      "ears-resolver",
+		},
+		"value mismatch": {
+			selectors: []string{"zoo.knative.dev/swims=true"},
+		},
+		"negated requirement": {
+			selectors: []string{"zoo.knative.dev/ears!=true"},
+		},
+		"match expressions": {
+			selectors: []string{"zoo.knative.dev/hops in (yes),zoo.knative.dev/legs=4"},
+			want:      "hops-resolver",
+		},
+		"set based": {
+			selectors: []string{"zoo.knative.dev/swims in (true,false)"},
+			want:      "land-resolver",
+		},
+		"any selector": {
+			selectors: []string{"zoo.knative.dev/bill=true", "zoo.knative.dev/ears=true"},
+			want:      "ears-resolver",
+		},
+		"ambiguous": {
+			selectors: []string{"zoo.knative.dev/hops=true"},
+			ambiguous: []string{"hops-resolver", "jumps-resolver"},
+		},
+		"ambiguous across selectors": {
+			selectors: []string{"zoo.knative.dev/ears=true", "zoo.knative.dev/hops=yes"},
+			ambiguous: []string{"ears-resolver", "hops-resolver"},
+		},
+		"invalid selector": {
+			selectors: []string{"zoo.knative.dev/ears=="},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			selectors := make([]v1alpha1.CustomResourceDefinitionSelector, 0, len(tc.selectors))
+			for _, ls := range tc.selectors {
+				selectors = append(selectors, v1alpha1.CustomResourceDefinitionSelector{LabelSelector: ls})
+			}
+
+			got, err := getClusterRoleAggregating(selectors, roles)
+			var ambiguous *ambiguousRoleError
+			if errors.As(err, &ambiguous) {
+				if diff := cmp.Diff(tc.ambiguous, ambiguous.names); diff != "" {
+					t.Error("ambiguous (-want, +got):", diff)
+				}
+				return
+			} else if err != nil {
+				t.Fatal("unexpected error:", err)
+			} else if tc.ambiguous != nil {
+				t.Fatal("expected ambiguous roles", tc.ambiguous)
+			}
+
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tc.want {
+				t.Errorf("got role %q, want %q", name, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// FilterCRDs narrows the CRDs that matched the label selector of st to the
+// CRDs that also match its annotation selector and field filters.
+func FilterCRDs(crds []*apiextensionsv1.CustomResourceDefinition, st v1alpha1.CustomResourceDefinitionSelector) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	annotations, err := labels.Parse(st.AnnotationSelector)
+	if err != nil {
+		return nil, err
+	}
+	groups := sets.NewString(st.Groups...)
+	kinds := sets.NewString(st.Kinds...)
+	categories := sets.NewString(st.Categories...)
+
+	filtered := make([]*apiextensionsv1.CustomResourceDefinition, 0, len(crds))
+	for _, crd := range crds {
+		switch {
+		case !annotations.Matches(labels.Set(crd.Annotations)):
+		case groups.Len() > 0 && !groups.Has(crd.Spec.Group):
+		case st.Scope != "" && string(st.Scope) != string(crd.Spec.Scope):
+		case kinds.Len() > 0 && !kinds.Has(crd.Spec.Names.Kind):
+		case categories.Len() > 0 && !categories.HasAny(crd.Spec.Names.Categories...):
+		default:
+			filtered = append(filtered, crd)
+		}
+	}
+	return filtered, nil
+}
+
+// SelectCRDs combines the CRDs matching the selectors of the spec as set by
+// its selector mode, and leaves out the CRDs matching any of its exclude
+// selectors. Each CRD is returned once, in the order it was first matched.
+// getCRDsWith lists the CRDs matching a selector.
+func SelectCRDs(spec *v1alpha1.ClusterDuckTypeSpec, getCRDsWith func(v1alpha1.CustomResourceDefinitionSelector) ([]*apiextensionsv1.CustomResourceDefinition, error)) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	selected := make([]*apiextensionsv1.CustomResourceDefinition, 0)
+	matches := make(map[string]int)
+	for _, st := range spec.Selectors {
+		crds, err := getCRDsWith(st)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", st.LabelSelector, err)
+		}
+		// A selector may list a CRD more than once.
+		seen := sets.NewString()
+		for _, crd := range crds {
+			if seen.Has(crd.Name) {
+				continue
+			}
+			seen.Insert(crd.Name)
+			if matches[crd.Name] == 0 {
+				selected = append(selected, crd)
+			}
+			matches[crd.Name]++
+		}
+	}
+
+	excluded := sets.NewString()
+	for _, st := range spec.ExcludeSelectors {
+		crds, err := getCRDsWith(st)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", st.LabelSelector, err)
+		}
+		for _, crd := range crds {
+			excluded.Insert(crd.Name)
+		}
+	}
+
+	filtered := make([]*apiextensionsv1.CustomResourceDefinition, 0, len(selected))
+	for _, crd := range selected {
+		if excluded.Has(crd.Name) {
+			continue
+		}
+		if spec.SelectorMode == v1alpha1.IntersectionSelectorMode && matches[crd.Name] < len(spec.Selectors) {
+			continue
+		}
+		filtered = append(filtered, crd)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterducktype
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func crd(group, kind string, scope apiextensionsv1.ResourceScope, annotations map[string]string, categories ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kind + "." + group,
+			Annotations: annotations,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Scope: scope,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:       kind,
+				Categories: categories,
+			},
+		},
+	}
+}
+
+func TestFilterCRDs(t *testing.T) {
+	duck := crd("north.america", "Duck", apiextensionsv1.NamespaceScoped, nil, "birds", "swimmers")
+	gila := crd("north.america", "GilaMonster", apiextensionsv1.ClusterScoped, map[string]string{"zoo.knative.dev/vendor": "acme"})
+	platypus := crd("australia", "Platypus", apiextensionsv1.NamespaceScoped, map[string]string{"zoo.knative.dev/vendor": "other"}, "swimmers")
+	crds := []*apiextensionsv1.CustomResourceDefinition{duck, gila, platypus}
+
+	tests := map[string]struct {
+		selector v1alpha1.CustomResourceDefinitionSelector
+		want     []*apiextensionsv1.CustomResourceDefinition
+	}{
+		"no filters": {
+			want: crds,
+		},
+		"annotation selector": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{AnnotationSelector: "zoo.knative.dev/vendor in (acme)"},
+			want:     []*apiextensionsv1.CustomResourceDefinition{gila},
+		},
+		"annotation exists": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{AnnotationSelector: "zoo.knative.dev/vendor"},
+			want:     []*apiextensionsv1.CustomResourceDefinition{gila, platypus},
+		},
+		"groups": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{Groups: []string{"australia", "africa"}},
+			want:     []*apiextensionsv1.CustomResourceDefinition{platypus},
+		},
+		"scope": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{Scope: v1alpha1.ClusterScoped},
+			want:     []*apiextensionsv1.CustomResourceDefinition{gila},
+		},
+		"kinds": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{Kinds: []string{"Duck", "Platypus"}},
+			want:     []*apiextensionsv1.CustomResourceDefinition{duck, platypus},
+		},
+		"categories": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{Categories: []string{"swimmers"}},
+			want:     []*apiextensionsv1.CustomResourceDefinition{duck, platypus},
+		},
+		"all filters": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{
+				Groups:     []string{"north.america"},
+				Scope:      v1alpha1.NamespaceScoped,
+				Categories: []string{"birds"},
+			},
+			want: []*apiextensionsv1.CustomResourceDefinition{duck},
+		},
+		"nothing matches": {
+			selector: v1alpha1.CustomResourceDefinitionSelector{Groups: []string{"antarctica"}},
+			want:     []*apiextensionsv1.CustomResourceDefinition{},
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := FilterCRDs(crds, tc.selector)
+			if err != nil {
+				t.Fatal("FilterCRDs() =", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("FilterCRDs (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestFilterCRDs_BadAnnotationSelector(t *testing.T) {
+	if _, err := FilterCRDs(nil, v1alpha1.CustomResourceDefinitionSelector{AnnotationSelector: "turn down for duck"}); err == nil {
+		t.Error("FilterCRDs() = nil, wanted an error")
+	}
+}
+
+func TestSelectCRDs(t *testing.T) {
+	duck := crd("north.america", "Duck", apiextensionsv1.NamespaceScoped, nil)
+	gila := crd("north.america", "GilaMonster", apiextensionsv1.ClusterScoped, nil)
+	platypus := crd("australia", "Platypus", apiextensionsv1.NamespaceScoped, nil)
+
+	// Selectors pick the CRDs by label selector, platypi are listed twice.
+	byLabel := map[string][]*apiextensionsv1.CustomResourceDefinition{
+		"swims":  {duck, platypus, platypus},
+		"bill":   {platypus, duck},
+		"scales": {gila},
+		"furry":  {platypus},
+	}
+	getCRDsWith := func(st v1alpha1.CustomResourceDefinitionSelector) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+		return FilterCRDs(byLabel[st.LabelSelector], st)
+	}
+
+	tests := map[string]struct {
+		spec v1alpha1.ClusterDuckTypeSpec
+		want []*apiextensionsv1.CustomResourceDefinition
+	}{
+		"no selectors": {
+			want: []*apiextensionsv1.CustomResourceDefinition{},
+		},
+		"union": {
+			spec: v1alpha1.ClusterDuckTypeSpec{
+				Selectors: []v1alpha1.CustomResourceDefinitionSelector{
+					{LabelSelector: "swims"}, {LabelSelector: "bill"}, {LabelSelector: "scales"},
+				},
+			},
+			want: []*apiextensionsv1.CustomResourceDefinition{duck, platypus, gila},
+		},
+		"intersection": {
+			spec: v1alpha1.ClusterDuckTypeSpec{
+				Selectors: []v1alpha1.CustomResourceDefinitionSelector{
+					{LabelSelector: "swims"}, {LabelSelector: "bill"}, {LabelSelector: "furry"},
+				},
+				SelectorMode: v1alpha1.IntersectionSelectorMode,
+			},
+			want: []*apiextensionsv1.CustomResourceDefinition{platypus},
+		},
+		"exclude": {
+			spec: v1alpha1.ClusterDuckTypeSpec{
+				Selectors: []v1alpha1.CustomResourceDefinitionSelector{
+					{LabelSelector: "swims"}, {LabelSelector: "scales"},
+				},
+				ExcludeSelectors: []v1alpha1.CustomResourceDefinitionSelector{
+					{LabelSelector: "furry"}, {LabelSelector: "scales", Scope: v1alpha1.NamespaceScoped},
+				},
+			},
+			want: []*apiextensionsv1.CustomResourceDefinition{duck, gila},
+		},
+		"intersection and exclude": {
+			spec: v1alpha1.ClusterDuckTypeSpec{
+				Selectors: []v1alpha1.CustomResourceDefinitionSelector{
+					{LabelSelector: "swims"}, {LabelSelector: "bill"},
+				},
+				ExcludeSelectors: []v1alpha1.CustomResourceDefinitionSelector{
+					{LabelSelector: "furry"},
+				},
+				SelectorMode: v1alpha1.IntersectionSelectorMode,
+			},
+			want: []*apiextensionsv1.CustomResourceDefinition{duck},
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := SelectCRDs(&tc.spec, getCRDsWith)
+			if err != nil {
+				t.Fatal("SelectCRDs() =", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("SelectCRDs (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestSelectCRDs_Error(t *testing.T) {
+	spec := &v1alpha1.ClusterDuckTypeSpec{
+		Selectors: []v1alpha1.CustomResourceDefinitionSelector{{LabelSelector: "swims"}},
+	}
+	_, err := SelectCRDs(spec, func(v1alpha1.CustomResourceDefinitionSelector) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+		return nil, errors.New("boom")
+	})
+	if got, want := fmt.Sprint(err), `"swims": boom`; got != want {
+		t.Errorf("SelectCRDs() = %s, wanted %s", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+Feature: Reconcile ClusterDuckType discovering built-in kinds
+
+    Scenario: Reconciling a ClusterDuckType matching built-in kinds by schema
+
+        Given the following objects (from file):
+            | file                           |
+            | config/builtins/animals.yaml   |
+            | config/builtins/initial.yaml   |
+
+        And a ClusterDuckType reconciler
+
+        When reconciling "podspecables.duck.knative.dev"
+
+        Then expect status updates (from file):
+            | file                           |
+            | config/builtins/updated.yaml   |
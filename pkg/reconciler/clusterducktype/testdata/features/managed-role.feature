@@ -0,0 +1,59 @@
+Feature: Maintain the managed ClusterRole of a ClusterDuckType
+
+    Scenario: Creating the managed ClusterRole
+
+        Given the following objects (from file):
+            | file                        |
+            | config/zoo/animals.yaml     |
+            | config/managed/paddlers.yaml |
+
+        And a ClusterDuckType reconciler
+
+        When reconciling "paddlers.zoo.knative.dev"
+
+        Then expect creates (from file):
+            | file                     |
+            | config/managed/role.yaml |
+
+        And expect status updates (from file):
+            | file                                 |
+            | config/managed/updated-paddlers.yaml |
+
+    Scenario: Updating a stale managed ClusterRole
+
+        Given the following objects (from file):
+            | file                           |
+            | config/zoo/animals.yaml        |
+            | config/managed/paddlers.yaml   |
+            | config/managed/stale-role.yaml |
+
+        And a ClusterDuckType reconciler
+
+        When reconciling "paddlers.zoo.knative.dev"
+
+        Then expect updates (from file):
+            | file                     |
+            | config/managed/role.yaml |
+
+        And expect status updates (from file):
+            | file                                 |
+            | config/managed/updated-paddlers.yaml |
+
+    Scenario: Deleting the managed ClusterRole once it is no longer asked for
+
+        Given the following objects (from file):
+            | file                        |
+            | config/zoo/animals.yaml     |
+            | config/managed/walkers.yaml |
+
+        And a ClusterDuckType reconciler
+
+        When reconciling "walkers.zoo.knative.dev"
+
+        Then expect deletes:
+            | Resource     | Namespace | Name                          |
+            | clusterroles |           | walkers.zoo.knative.dev-ducks |
+
+        And expect status updates (from file):
+            | file                                |
+            | config/managed/updated-walkers.yaml |
@@ -0,0 +1,36 @@
+Feature: Reconcile ClusterDuckType with unresolved refs
+
+    Scenario: Reconciling ClusterDuckType strays.zoo.knative.dev
+
+        Given the following objects (from file):
+            | file                     |
+            | config/zoo/animals.yaml  |
+            | config/zoo/initial.yaml  |
+            | config/zoo/clusterroles.yaml  |
+
+        And a ClusterDuckType reconciler
+
+        When reconciling "strays.zoo.knative.dev"
+
+        Then expect status updates (from file):
+            | file                           |
+            | config/zoo/updated-strays.yaml |
+
+        And expect Kubernetes Events:
+            | Type    | Reason       | Message                                                                               |
+            | Warning | RefsNotFound | Unable to resolve refs: v1: resource "Unicorn north.america/v1" not known to the cluster |
+
+    Scenario: Reconciling ClusterDuckType lurkers.zoo.knative.dev
+
+        Given the following objects (from file):
+            | file                     |
+            | config/zoo/animals.yaml  |
+            | config/zoo/initial.yaml  |
+
+        And a ClusterDuckType reconciler
+
+        When reconciling "lurkers.zoo.knative.dev"
+
+        Then expect status updates (from file):
+            | file                            |
+            | config/zoo/updated-lurkers.yaml |
@@ -0,0 +1,16 @@
+Feature: Reconcile ClusterDuckType with a duck schema
+
+    Scenario: Reconciling a ClusterDuckType with a schema
+
+        Given the following objects (from file):
+            | file                        |
+            | config/schema/animals.yaml  |
+            | config/schema/initial.yaml  |
+
+        And a ClusterDuckType reconciler
+
+        When reconciling "callables.zoo.knative.dev"
+
+        Then expect status updates (from file):
+            | file                        |
+            | config/schema/updated.yaml  |
@@ -22,3 +22,6 @@ Feature: Reconcile ClusterDuckType in a Zoo
             | furries.zoo.knative.dev    | config/zoo/updated-furries.yaml    |
             | bills.zoo.knative.dev      | config/zoo/updated-bills.yaml      |
             | swimmers.zoo.knative.dev   | config/zoo/updated-swimmers.yaml   |
+            | waders.zoo.knative.dev     | config/zoo/updated-waders.yaml     |
+            | bathers.zoo.knative.dev    | config/zoo/updated-bathers.yaml    |
+            | climbers.zoo.knative.dev   | config/zoo/updated-climbers.yaml   |
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ducktype
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ducktypeinformer "knative.dev/discovery/pkg/client/injection/informers/discovery/v1alpha1/ducktype"
+	ducktypereconciler "knative.dev/discovery/pkg/client/injection/reconciler/discovery/v1alpha1/ducktype"
+	"knative.dev/discovery/pkg/reconciler/clusterducktype"
+	crdinformer "knative.dev/pkg/client/injection/apiextensions/informers/apiextensions/v1/customresourcedefinition"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	clusterroleinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/clusterrole"
+	clusterrolebindinginformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/clusterrolebinding"
+	roleinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/role"
+	rolebindinginformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/rolebinding"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewController creates a Reconciler and returns the result of NewImpl.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	ducktypeInformer := ducktypeinformer.Get(ctx)
+	crdInformer := crdinformer.Get(ctx)
+	roleInformer := roleinformer.Get(ctx)
+	roleBindingInformer := rolebindinginformer.Get(ctx)
+	clusterRoleInformer := clusterroleinformer.Get(ctx)
+	clusterRoleBindingInformer := clusterrolebindinginformer.Get(ctx)
+
+	r := &Reconciler{
+		client:            kubeclient.Get(ctx),
+		crdLister:         crdInformer.Lister(),
+		roleLister:        roleInformer.Lister(),
+		roleBindingLister: roleBindingInformer.Lister(),
+
+		clusterRoleLister:        clusterRoleInformer.Lister(),
+		clusterRoleBindingLister: clusterRoleBindingInformer.Lister(),
+	}
+	impl := ducktypereconciler.NewImpl(ctx, r)
+
+	// Map the resources served by the cluster, and resync when the group
+	// versions that could not be discovered come back.
+	r.mappers = clusterducktype.NewResourceMapperSync(kubeclient.Get(ctx).Discovery(), func() {
+		impl.GlobalResync(ducktypeInformer.Informer())
+	})
+	r.mappers.Resync(ctx)
+
+	logger.Info("Setting up event handlers.")
+
+	ducktypeInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Watch custom resource definitions. The events of a burst, like the CRDs
+	// of a release being installed, are batched so the resources are
+	// rediscovered once.
+	window, err := clusterducktype.ResyncWindow()
+	if err != nil {
+		logger.Fatalw("Unable to read the CRD resync window", zap.Error(err))
+	}
+	grDt := clusterducktype.NewBatcher(window, func([]interface{}) {
+		r.mappers.Resync(ctx)
+		impl.GlobalResync(ducktypeInformer.Informer())
+	})
+	crdInformer.Informer().AddEventHandler(controller.HandleAll(grDt.Add))
+
+	// Watch the RBAC of each namespace, it decides which ducks are usable.
+	grNs := func(obj interface{}) {
+		if object, ok := obj.(metav1.Object); ok {
+			impl.FilteredGlobalResync(func(dt interface{}) bool {
+				return dt.(metav1.Object).GetNamespace() == object.GetNamespace()
+			}, ducktypeInformer.Informer())
+		}
+	}
+	roleInformer.Informer().AddEventHandler(controller.HandleAll(grNs))
+	roleBindingInformer.Informer().AddEventHandler(controller.HandleAll(grNs))
+
+	// ClusterRoles and ClusterRoleBindings apply to every namespace.
+	grAll := func(obj interface{}) {
+		impl.GlobalResync(ducktypeInformer.Informer())
+	}
+	clusterRoleInformer.Informer().AddEventHandler(controller.HandleAll(grAll))
+	clusterRoleBindingInformer.Informer().AddEventHandler(controller.HandleAll(grAll))
+
+	return impl
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ducktype
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionslisters "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+	"knative.dev/discovery/pkg/collection"
+	"knative.dev/discovery/pkg/reconciler/clusterducktype"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	ducktypereconciler "knative.dev/discovery/pkg/client/injection/reconciler/discovery/v1alpha1/ducktype"
+	"knative.dev/pkg/reconciler"
+)
+
+// Reconciler implements ducktypereconciler.Interface for
+// DuckType resources.
+type Reconciler struct {
+	client            kubernetes.Interface
+	crdLister         apiextensionslisters.CustomResourceDefinitionLister
+	roleLister        rbaclisters.RoleLister
+	roleBindingLister rbaclisters.RoleBindingLister
+
+	// clusterRoleLister and clusterRoleBindingLister find the ClusterRoles
+	// that apply to the namespace.
+	clusterRoleLister        rbaclisters.ClusterRoleLister
+	clusterRoleBindingLister rbaclisters.ClusterRoleBindingLister
+
+	// mappers keeps the resource mapper in sync with the resources served by
+	// the cluster.
+	mappers *clusterducktype.ResourceMapperSync
+}
+
+// Check that our Reconciler implements Interface
+var _ ducktypereconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface
+func (r *Reconciler) ReconcileKind(ctx context.Context, dt *v1alpha1.DuckType) reconciler.Event {
+	// Make a safe copy of the resource mapper.
+	rm, err := r.mappers.Mapper()
+	if err != nil {
+		// Keep hunting, the ducks of the missing group versions are not found.
+		dt.Status.MarkDiscoveryUnhealthy("DiscoveryFailed", "Ducks may be missing: %v", err)
+	} else {
+		dt.Status.MarkDiscoveryHealthy()
+	}
+
+	role, err := r.getNamespaceRole(dt)
+	var notBound *roleNotBoundError
+	if apierrs.IsNotFound(err) {
+		// Keep hunting, no ducks are usable from the namespace.
+		role = nil
+		dt.Status.MarkRoleUnresolved("RoleNotFound", "%s %q not found", dt.Spec.Role.RoleRef.Kind, dt.Spec.Role.RoleRef.Name)
+	} else if errors.As(err, &notBound) {
+		// Keep hunting, no ducks are usable from the namespace.
+		role = nil
+		dt.Status.MarkRoleUnresolved("RoleNotBound", "%v", err)
+	} else if err != nil {
+		dt.Status.MarkRoleUnresolved("RoleLookupFailed", "Unable to get the namespace roles: %v", err)
+		return err
+	} else if len(role.Rules) == 0 {
+		dt.Status.MarkRoleResolvedWithReason("NoRules", "No roles grant access in namespace %q", dt.Namespace)
+	} else {
+		dt.Status.MarkRoleResolved()
+	}
+	// Set up this instance of a duck hunter. The namespace role stands in for
+	// the aggregating ClusterRole so the hunter can tell which ducks are
+	// accessible from the namespace.
+	hunter := collection.NewDuckHunter(rm, dt.Spec.Versions, &collection.DuckFilters{
+		DuckLabel:         fmt.Sprintf("%s/%s", dt.Spec.Group, dt.Spec.Names.Singular),
+		DuckVersionPrefix: fmt.Sprintf("%s.%s", dt.Spec.Names.Plural, dt.Spec.Group),
+	}, role,
+	)
+
+	// By query
+
+	crds, err := clusterducktype.SelectCRDs(&dt.Spec, r.getCRDsWith)
+	if err != nil {
+		dt.Status.MarkCRDsNotDiscovered("CRDListFailed", "Unable to list CRDs with %v", err)
+		return err
+	}
+	hunter.AddCRDs(crds)
+	dt.Status.MarkCRDsDiscovered()
+
+	// By ref
+
+	if unresolved := clusterducktype.AddRefs(ctx, hunter, dt.Spec.Versions); len(unresolved) > 0 {
+		dt.Status.MarkRefsUnresolved("RefsNotFound", "Unable to resolve refs: %s", clusterducktype.UnresolvedRefsMessage(unresolved))
+	} else if mismatches := hunter.RefScopeMismatches(); len(mismatches) > 0 {
+		dt.Status.MarkRefsResolvedWithReason("ScopeMismatch", "Scope of refs taken from the cluster: %s", collection.RefScopeMismatchesMessage(mismatches))
+	} else {
+		dt.Status.MarkRefsResolved()
+	}
+
+	dt.Status.Ducks = usableDucks(hunter.Ducks())
+	if dt.Spec.Role != nil && dt.Spec.Role.Subject != nil {
+		if err := clusterducktype.ReviewAccess(ctx, r.client, dt.Spec.Role.Subject, dt.Namespace, dt.Status.Ducks); err != nil {
+			dt.Status.MarkRoleUnresolved("AccessReviewFailed", "Unable to review the access of the subject: %v", err)
+			return err
+		}
+	}
+	dt.Status.DuckCount = clusterducktype.DuckCount(dt.Status.Ducks)
+	return nil
+}
+
+// usableDucks filters the ducks down to the namespaced resources the namespace
+// role can get, list and watch.
+func usableDucks(ducks map[string][]v1alpha1.ResourceMeta) map[string][]v1alpha1.ResourceMeta {
+	usable := make(map[string][]v1alpha1.ResourceMeta, len(ducks))
+	for version, metas := range ducks {
+		for _, meta := range metas {
+			if meta.Scope == v1alpha1.NamespaceScoped && meta.AccessibleViaClusterRole {
+				usable[version] = append(usable[version], meta)
+			}
+		}
+	}
+	if len(usable) == 0 {
+		return nil
+	}
+	return usable
+}
+
+// roleNotBoundError is returned when the ClusterRole referenced by a DuckType
+// is not bound in its namespace.
+type roleNotBoundError struct {
+	name      string
+	namespace string
+}
+
+func (e *roleNotBoundError) Error() string {
+	return fmt.Sprintf("ClusterRole %q is not bound in namespace %q", e.name, e.namespace)
+}
+
+// getNamespaceRole returns a ClusterRole holding the rules that apply to the
+// namespace of the DuckType.
+//   If Spec.Role.RoleRef is set, the rules come from the referenced Role or
+//   ClusterRole, and a NotFound error is returned if it does not exist. A
+//   ClusterRole only applies if it is bound in the namespace, otherwise a
+//   roleNotBoundError is returned.
+//   Otherwise the rules of every role bound in the namespace are collected.
+//   If Spec.Role.Subject is set, only the bindings of the subject count:
+//   RoleBindings in the namespace and ClusterRoleBindings. Without a subject,
+//   only the RoleBindings of the namespace's service accounts count: those of
+//   its default ServiceAccount or its system:serviceaccounts:<namespace>
+//   group.
+func (r *Reconciler) getNamespaceRole(dt *v1alpha1.DuckType) (*rbacv1.ClusterRole, error) {
+	var subject *rbacv1.Subject
+	if dt.Spec.Role != nil {
+		subject = dt.Spec.Role.Subject
+	}
+	bound, err := r.getBoundRoles(dt.Namespace, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := bound
+	explicit := dt.Spec.Role != nil && dt.Spec.Role.RoleRef != nil
+	if explicit {
+		ref := *dt.Spec.Role.RoleRef
+		if ref.Kind == "ClusterRole" && !hasRoleRef(bound, ref) {
+			if _, err := r.clusterRoleLister.Get(ref.Name); err != nil {
+				return nil, err
+			}
+			return nil, &roleNotBoundError{name: ref.Name, namespace: dt.Namespace}
+		}
+		refs = []rbacv1.RoleRef{ref}
+	}
+
+	role := &rbacv1.ClusterRole{}
+	for _, ref := range refs {
+		rules, err := r.getRules(dt.Namespace, ref)
+		if apierrs.IsNotFound(err) && !explicit {
+			// A binding to a missing role grants nothing.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		role.Rules = append(role.Rules, rules...)
+	}
+	return role, nil
+}
+
+// getBoundRoles returns the roles bound in the namespace: by the RoleBindings
+// of the namespace and, if subject is set, by the ClusterRoleBindings. If
+// subject is set, only the bindings of the subject are considered, otherwise
+// only those of the service accounts of the namespace.
+func (r *Reconciler) getBoundRoles(namespace string, subject *rbacv1.Subject) ([]rbacv1.RoleRef, error) {
+	refs := make([]rbacv1.RoleRef, 0)
+	bindings, err := r.roleBindingLister.RoleBindings(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, rb := range bindings {
+		if subject == nil && bindsNamespace(rb.Subjects, namespace) || subject != nil && bindsSubject(rb.Subjects, namespace, subject) {
+			refs = append(refs, rb.RoleRef)
+		}
+	}
+	if subject == nil {
+		// ClusterRoleBindings bind the roles of many subjects cluster-wide,
+		// only those of a given subject apply to the namespace.
+		return refs, nil
+	}
+
+	clusterBindings, err := r.clusterRoleBindingLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, crb := range clusterBindings {
+		if bindsSubject(crb.Subjects, "", subject) {
+			refs = append(refs, crb.RoleRef)
+		}
+	}
+	return refs, nil
+}
+
+// bindsSubject reports whether the subjects of a binding include subject.
+// ServiceAccounts without a namespace in a RoleBinding are in the namespace of
+// the binding.
+func bindsSubject(subjects []rbacv1.Subject, namespace string, subject *rbacv1.Subject) bool {
+	for _, s := range subjects {
+		if s.Kind != subject.Kind || s.Name != subject.Name {
+			continue
+		}
+		if s.Kind != rbacv1.ServiceAccountKind {
+			return true
+		}
+		ns := s.Namespace
+		if ns == "" {
+			ns = namespace
+		}
+		if ns == subject.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// bindsNamespace reports whether the subjects of a RoleBinding in the
+// namespace include its default ServiceAccount or the group of its service
+// accounts. The bindings of other users do not apply to the namespace as a
+// whole.
+func bindsNamespace(subjects []rbacv1.Subject, namespace string) bool {
+	defaultAccount := &rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "default", Namespace: namespace}
+	serviceAccounts := &rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:" + namespace}
+	return bindsSubject(subjects, namespace, defaultAccount) || bindsSubject(subjects, namespace, serviceAccounts)
+}
+
+// hasRoleRef reports whether refs holds ref.
+func hasRoleRef(refs []rbacv1.RoleRef, ref rbacv1.RoleRef) bool {
+	for _, r := range refs {
+		if r.Kind == ref.Kind && r.Name == ref.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// getRules fetches the rules of the Role or ClusterRole referenced by ref.
+func (r *Reconciler) getRules(namespace string, ref rbacv1.RoleRef) ([]rbacv1.PolicyRule, error) {
+	switch ref.Kind {
+	case "Role":
+		role, err := r.roleLister.Roles(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		return role.Rules, nil
+	case "ClusterRole":
+		role, err := r.clusterRoleLister.Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		return role.Rules, nil
+	}
+	return nil, nil
+}
+
+// getCRDsWith returns CRDs labeled as given, narrowed by the annotation
+// selector and field filters of the selector.
+// st.LabelSelector should be in the form "<group>/<names.singular>=true"
+func (r *Reconciler) getCRDsWith(st v1alpha1.CustomResourceDefinitionSelector) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	ls, err := labels.Parse(st.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := r.crdLister.List(ls)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterducktype.FilterCRDs(list, st)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ducktype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func rule(resource string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{"north.america"},
+		Resources: []string{resource},
+		Verbs:     []string{"get", "list", "watch"},
+	}
+}
+
+func TestGetNamespaceRole(t *testing.T) {
+	app := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "app", Namespace: "tenant"}
+	developers := rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "developers"}
+
+	clusterRoles := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, cr := range []*rbacv1.ClusterRole{{
+		ObjectMeta: metav1.ObjectMeta{Name: "duck-viewer"},
+		Rules:      []rbacv1.PolicyRule{rule("ducks")},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "goose-viewer"},
+		Rules:      []rbacv1.PolicyRule{rule("geese")},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+	}} {
+		_ = clusterRoles.Add(cr)
+	}
+	clusterRoleBindings := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = clusterRoleBindings.Add(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "goose-viewer"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "goose-viewer"},
+		Subjects:   []rbacv1.Subject{app},
+	})
+	_ = clusterRoleBindings.Add(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}},
+	})
+	roleBindings := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	_ = roleBindings.Add(&rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "duck-viewer", Namespace: "tenant"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "duck-viewer"},
+		Subjects:   []rbacv1.Subject{developers, {Kind: rbacv1.ServiceAccountKind, Name: "default"}},
+	})
+	_ = roleBindings.Add(&rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "mallory-admin", Namespace: "tenant"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "mallory"}},
+	})
+
+	r := &Reconciler{
+		roleLister:               rbaclisters.NewRoleLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		roleBindingLister:        rbaclisters.NewRoleBindingLister(roleBindings),
+		clusterRoleLister:        rbaclisters.NewClusterRoleLister(clusterRoles),
+		clusterRoleBindingLister: rbaclisters.NewClusterRoleBindingLister(clusterRoleBindings),
+	}
+
+	tests := map[string]struct {
+		role         *v1alpha1.Role
+		want         []rbacv1.PolicyRule
+		wantNotBound bool
+		wantNotFound bool
+	}{
+		// The binding of mallory does not apply to the namespace.
+		"role bindings of the service accounts of the namespace": {
+			want: []rbacv1.PolicyRule{rule("ducks")},
+		},
+		"bindings of a service account": {
+			role: &v1alpha1.Role{Subject: &app},
+			want: []rbacv1.PolicyRule{rule("geese")},
+		},
+		"bindings of a group": {
+			role: &v1alpha1.Role{Subject: &developers},
+			want: []rbacv1.PolicyRule{rule("ducks")},
+		},
+		"cluster role bound in the namespace": {
+			role: &v1alpha1.Role{RoleRef: &rbacv1.RoleRef{Kind: "ClusterRole", Name: "duck-viewer"}},
+			want: []rbacv1.PolicyRule{rule("ducks")},
+		},
+		"cluster role bound to the subject": {
+			role: &v1alpha1.Role{RoleRef: &rbacv1.RoleRef{Kind: "ClusterRole", Name: "goose-viewer"}, Subject: &app},
+			want: []rbacv1.PolicyRule{rule("geese")},
+		},
+		"cluster role not bound in the namespace": {
+			role:         &v1alpha1.Role{RoleRef: &rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"}},
+			wantNotBound: true,
+		},
+		"cluster role not bound to the subject": {
+			role:         &v1alpha1.Role{RoleRef: &rbacv1.RoleRef{Kind: "ClusterRole", Name: "duck-viewer"}, Subject: &app},
+			wantNotBound: true,
+		},
+		"missing cluster role": {
+			role:         &v1alpha1.Role{RoleRef: &rbacv1.RoleRef{Kind: "ClusterRole", Name: "missing"}},
+			wantNotFound: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dt := &v1alpha1.DuckType{ObjectMeta: metav1.ObjectMeta{Name: "swimmers.zoo.knative.dev", Namespace: "tenant"}}
+			dt.Spec.Role = tc.role
+
+			got, err := r.getNamespaceRole(dt)
+			var notBound *roleNotBoundError
+			if errors.As(err, &notBound) != tc.wantNotBound {
+				t.Fatalf("getNamespaceRole() error = %v, want not bound %t", err, tc.wantNotBound)
+			}
+			if apierrs.IsNotFound(err) != tc.wantNotFound {
+				t.Fatalf("getNamespaceRole() error = %v, want not found %t", err, tc.wantNotFound)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.Rules); diff != "" {
+				t.Error("rules (-want, +got):", diff)
+			}
+		})
+	}
+}
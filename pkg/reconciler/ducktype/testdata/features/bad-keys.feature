@@ -0,0 +1,15 @@
+Feature: Reconcile DuckType
+
+    Scenario Outline: Reconciling <key> causes <result>.
+
+        Given the following objects:
+            """
+            """
+        And a DuckType reconciler
+        When reconciling "<key>"
+        Then expect <result>
+
+        Examples:
+            | key            | result  |
+            | too/many/parts | nothing |
+            | foo/not-found  | nothing |
@@ -0,0 +1,25 @@
+Feature: Reconcile DuckType for a tenant namespace
+
+    Scenario Outline: Reconciling DuckType <key>
+
+        Given the following objects (from file):
+            | file                         |
+            | config/tenant/animals.yaml   |
+            | config/tenant/rbac.yaml      |
+            | config/tenant/initial.yaml   |
+
+        And a DuckType reconciler
+
+        When reconciling "<key>"
+
+        Then expect status updates (from file):
+            | file      |
+            | <updated> |
+
+        Examples:
+            | key                             | updated                            |
+            | tenant/swimmers.zoo.knative.dev | config/tenant/updated-tenant.yaml  |
+            | reef/swimmers.zoo.knative.dev   | config/tenant/updated-reef.yaml    |
+            | empty/swimmers.zoo.knative.dev  | config/tenant/updated-empty.yaml   |
+            | lost/swimmers.zoo.knative.dev   | config/tenant/updated-lost.yaml    |
+            | open/swimmers.zoo.knative.dev   | config/tenant/updated-open.yaml    |
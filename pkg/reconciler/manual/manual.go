@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manual
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+	manualreconciler "knative.dev/discovery/pkg/client/injection/reconciler/discovery/v1alpha1/manual"
+	discoverylisters "knative.dev/discovery/pkg/client/listers/discovery/v1alpha1"
+	"knative.dev/pkg/reconciler"
+)
+
+// Reconciler implements manualreconciler.Interface for
+// Manual resources.
+type Reconciler struct {
+	duckTypeLister discoverylisters.ClusterDuckTypeLister
+}
+
+// Check that our Reconciler implements Interface
+var _ manualreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface
+func (r *Reconciler) ReconcileKind(ctx context.Context, m *v1alpha1.Manual) reconciler.Event {
+	missing, err := r.missingDuckTypes(m)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		m.Status.MarkDuckTypesUnresolved("DuckTypeNotFound", "ClusterDuckTypes not found: %s", strings.Join(missing, ", "))
+		return nil
+	}
+	m.Status.MarkDuckTypesResolved()
+	return nil
+}
+
+// missingDuckTypes returns the sorted names of the ClusterDuckTypes referenced
+// by the fields of the Manual that do not exist in the cluster, or that do not
+// have the referenced version.
+func (r *Reconciler) missingDuckTypes(m *v1alpha1.Manual) ([]string, error) {
+	seen := make(map[v1alpha1.DuckTypeReference]bool)
+	missing := make([]string, 0)
+	for _, v := range m.Spec.Versions {
+		for _, f := range v.Fields {
+			if f.DuckType == nil || seen[*f.DuckType] {
+				continue
+			}
+			seen[*f.DuckType] = true
+
+			dt, err := r.duckTypeLister.Get(f.DuckType.Name)
+			if apierrs.IsNotFound(err) {
+				missing = append(missing, f.DuckType.Name)
+			} else if err != nil {
+				return nil, err
+			} else if f.DuckType.Version != "" && !hasVersion(dt, f.DuckType.Version) {
+				missing = append(missing, fmt.Sprintf("%s version %s", f.DuckType.Name, f.DuckType.Version))
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// hasVersion reports whether the duck type has the version.
+func hasVersion(dt *v1alpha1.ClusterDuckType, version string) bool {
+	for _, v := range dt.Spec.Versions {
+		if v.Name == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manual
+
+import (
+	"context"
+	"testing"
+
+	"knative.dev/discovery/pkg/client/injection/reconciler/discovery/v1alpha1/manual"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/discovery/pkg/client/injection/client"
+	"knative.dev/discovery/pkg/reconciler/testing/featured"
+	. "knative.dev/discovery/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestMain(m *testing.M) {
+	featured.Run(m)
+}
+
+func TestReconcileKind(t *testing.T) {
+	featured.TestReconcileKind(t, "Manual", MakeFactory(func(ctx context.Context, listers *Listers, watcher configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			duckTypeLister: listers.GetClusterDuckTypeLister(),
+		}
+		return manual.NewReconciler(ctx, logging.FromContext(ctx),
+			client.Get(ctx), listers.GetManualLister(),
+			controller.GetEventRecorder(ctx), r)
+	}))
+}
@@ -0,0 +1,22 @@
+Feature: Reconcile Manual in a Library
+
+    Scenario Outline: Reconciling Manual <key>
+
+        Given the following objects (from file):
+            | file                          |
+            | config/manual/ducktypes.yaml  |
+            | config/manual/initial.yaml    |
+
+        And a Manual reconciler
+
+        When reconciling "<key>"
+
+        Then expect status updates (from file):
+            | file      |
+            | <updated> |
+
+        Examples:
+            | key                  | updated                             |
+            | triggers.example.com | config/manual/updated-triggers.yaml |
+            | pingers.example.com  | config/manual/updated-pingers.yaml  |
+            | sinks.example.com    | config/manual/updated-sinks.yaml    |
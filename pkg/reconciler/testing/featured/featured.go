@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientgotesting "k8s.io/client-go/testing"
 	. "knative.dev/discovery/pkg/reconciler/testing/v1alpha1"
 	pkgtest "knative.dev/pkg/reconciler/testing"
@@ -127,6 +128,9 @@ func ReconcileKindFeatureContext(t *testing.T, s *godog.ScenarioContext, kind st
 	s.Step(`^expect status updates:$`, rt.expectStatusUpdates)
 	s.Step(`^expect status updates \(from file\):$`, rt.expectStatusUpdateFiles)
 	s.Step(`^expect Kubernetes Events:$`, rt.expectKubernetesEvents)
+	s.Step(`^expect creates \(from file\):$`, rt.expectCreateFiles)
+	s.Step(`^expect updates \(from file\):$`, rt.expectUpdateFiles)
+	s.Step(`^expect deletes:$`, rt.expectDeletes)
 
 	s.AfterScenario(func(pickle *messages.Pickle, err error) {
 		originObjects := make([]runtime.Object, 0, len(rt.row.Objects))
@@ -161,6 +165,19 @@ type ReconcilerTest struct {
 }
 
 func (rt *ReconcilerTest) theFollowingObjectFiles(y *messages.PickleStepArgument_PickleTable) error {
+	objs, err := readObjectFiles(y)
+	if err != nil {
+		return err
+	}
+	rt.addObjects(objs)
+	return nil
+}
+
+// readObjectFiles parses the objects in the files listed in the first column
+// of the table. The other columns hold the config the files are templated
+// with, keyed by the header row.
+func readObjectFiles(y *messages.PickleStepArgument_PickleTable) ([]unstructured.Unstructured, error) {
+	objs := make([]unstructured.Unstructured, 0)
 	keys := make([]string, 0)
 	for row, v := range y.Rows {
 		var file string
@@ -184,40 +201,37 @@ func (rt *ReconcilerTest) theFollowingObjectFiles(y *messages.PickleStepArgument
 		}
 
 		// Leverage ParseTemplates to parse the template files.
-		// TODO: move this code around to let it be reusable
-		// in a more clear way.
 		if file != "" {
 			files, err := manifest.ParseTemplates(file, nil, config)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			list, err := ioutil.ReadDir(files)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			// len zero would be an invalid or missing file.
 			if len(list) == 0 {
-				return fmt.Errorf("expected to read a yaml file from %q but found none", file)
+				return nil, fmt.Errorf("expected to read a yaml file from %q but found none", file)
 			}
 			for _, f := range list {
 				name := path.Join(files, f.Name())
 				if !f.IsDir() {
 					ff, err := os.Open(name)
 					if err != nil {
-						return err
+						return nil, err
 					}
-					objs, err := ParseYAML(bufio.NewReader(ff))
+					o, err := ParseYAML(bufio.NewReader(ff))
 					if err != nil {
-						return err
+						return nil, err
 					}
-
-					rt.addObjects(objs)
+					objs = append(objs, o...)
 				}
 			}
 		}
 	}
-	return nil
+	return objs, nil
 }
 
 func (rt *ReconcilerTest) theFollowingObjects(y *messages.PickleStepArgument_PickleDocString) error {
@@ -264,57 +278,67 @@ func (rt *ReconcilerTest) expectStatusUpdates(y *messages.PickleStepArgument_Pic
 }
 
 func (rt *ReconcilerTest) expectStatusUpdateFiles(y *messages.PickleStepArgument_PickleTable) error {
-	keys := make([]string, 0)
-	for row, v := range y.Rows {
-		var file string
-		config := make(map[string]interface{})
-
-		for i, c := range v.Cells {
-			if row == 0 {
-				if i == 0 {
-					continue
-				}
-				keys = append(keys, c.Value)
-				continue
-			}
+	objs, err := readObjectFiles(y)
+	if err != nil {
+		return err
+	}
+	rt.addWantStatusUpdates(objs)
+	return nil
+}
 
-			switch i {
-			case 0:
-				file = "testdata/" + c.Value
-			default:
-				config[keys[i-1]] = c.Value
-			}
-		}
+func (rt *ReconcilerTest) expectCreateFiles(y *messages.PickleStepArgument_PickleTable) error {
+	objs, err := readObjectFiles(y)
+	if err != nil {
+		return err
+	}
+	creates := make([]runtime.Object, 0, len(objs))
+	for _, obj := range objs {
+		creates = append(creates, obj.DeepCopyObject())
+	}
+	for _, c := range ToKnownObjects(creates) {
+		// Typed objects are created without their kind.
+		c.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+		rt.row.WantCreates = append(rt.row.WantCreates, c)
+	}
+	return nil
+}
 
-		// Leverage ParseTemplates to parse the template files.
-		// TODO: move this code around to let it be reusable
-		// in a more clear way.
-		files, err := manifest.ParseTemplates(file, nil, config)
-		if err != nil {
-			return err
-		}
-		if file != "" {
-			list, err := ioutil.ReadDir(files)
-			if err != nil {
-				return err
-			}
+func (rt *ReconcilerTest) expectUpdateFiles(y *messages.PickleStepArgument_PickleTable) error {
+	objs, err := readObjectFiles(y)
+	if err != nil {
+		return err
+	}
+	updates := make([]runtime.Object, 0, len(objs))
+	for _, obj := range objs {
+		updates = append(updates, obj.DeepCopyObject())
+	}
+	for _, u := range ToKnownObjects(updates) {
+		rt.row.WantUpdates = append(rt.row.WantUpdates, clientgotesting.UpdateActionImpl{
+			Object: u,
+		})
+	}
+	return nil
+}
 
-			for _, f := range list {
-				name := path.Join(files, f.Name())
-				if !f.IsDir() {
-					ff, err := os.Open(name)
-					if err != nil {
-						return err
-					}
-					o, err := ParseYAML(bufio.NewReader(ff))
-					if err != nil {
-						return err
-					}
+func (rt *ReconcilerTest) expectDeletes(attributes *messages.PickleStepArgument_PickleTable) error {
+	for _, row := range attributes.Rows {
+		resource := row.Cells[0].Value
+		namespace := row.Cells[1].Value
+		name := row.Cells[2].Value
 
-					rt.addWantStatusUpdates(o)
-				}
-			}
+		if resource == "Resource" {
+			// ignore the headers
+			continue
 		}
+
+		rt.row.WantDeletes = append(rt.row.WantDeletes, clientgotesting.DeleteActionImpl{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: namespace,
+				Verb:      "delete",
+				Resource:  schema.GroupVersionResource{Resource: resource},
+			},
+			Name: name,
+		})
 	}
 	return nil
 }
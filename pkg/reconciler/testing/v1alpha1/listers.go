@@ -22,6 +22,7 @@ import (
 	"log"
 	"reflect"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	kubev1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	fakeapiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	kubev1lister "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	rbacv1lister "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
 	discoveryv1alpha1 "knative.dev/discovery/pkg/apis/discovery/v1alpha1"
 	fakesampleclientset "knative.dev/discovery/pkg/client/clientset/versioned/fake"
@@ -136,3 +138,27 @@ func (l *Listers) GetDiscoveryObjects() []runtime.Object {
 func (l *Listers) GetClusterDuckTypeLister() discoverylister.ClusterDuckTypeLister {
 	return discoverylister.NewClusterDuckTypeLister(l.IndexerFor(&discoveryv1alpha1.ClusterDuckType{}))
 }
+
+func (l *Listers) GetManualLister() discoverylister.ManualLister {
+	return discoverylister.NewManualLister(l.IndexerFor(&discoveryv1alpha1.Manual{}))
+}
+
+func (l *Listers) GetDuckTypeLister() discoverylister.DuckTypeLister {
+	return discoverylister.NewDuckTypeLister(l.IndexerFor(&discoveryv1alpha1.DuckType{}))
+}
+
+func (l *Listers) GetRoleLister() rbacv1lister.RoleLister {
+	return rbacv1lister.NewRoleLister(l.IndexerFor(&rbacv1.Role{}))
+}
+
+func (l *Listers) GetRoleBindingLister() rbacv1lister.RoleBindingLister {
+	return rbacv1lister.NewRoleBindingLister(l.IndexerFor(&rbacv1.RoleBinding{}))
+}
+
+func (l *Listers) GetClusterRoleLister() rbacv1lister.ClusterRoleLister {
+	return rbacv1lister.NewClusterRoleLister(l.IndexerFor(&rbacv1.ClusterRole{}))
+}
+
+func (l *Listers) GetClusterRoleBindingLister() rbacv1lister.ClusterRoleBindingLister {
+	return rbacv1lister.NewClusterRoleBindingLister(l.IndexerFor(&rbacv1.ClusterRoleBinding{}))
+}
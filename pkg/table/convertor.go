@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// now is used to compute the age of date columns, replaced in tests.
+var now = time.Now
+
+// Convertor renders instances of ducks as the rows of a metav1.Table, the
+// same way the API server renders custom resources with
+// additionalPrinterColumns.
+type Convertor struct {
+	headers []metav1.TableColumnDefinition
+	columns []*jsonpath.JSONPath
+}
+
+// NewConvertor creates a Convertor for the given printer columns. The table
+// always starts with a Name and a Kind column. If there are no printer
+// columns, an Age column is added, like for custom resources.
+func NewConvertor(columns []apiextensionsv1.CustomResourceColumnDefinition) (*Convertor, error) {
+	c := &Convertor{
+		headers: []metav1.TableColumnDefinition{{
+			Name:        "Name",
+			Type:        "string",
+			Format:      "name",
+			Description: metav1.ObjectMeta{}.SwaggerDoc()["name"],
+		}, {
+			Name:        "Kind",
+			Type:        "string",
+			Description: "Kind and group of the instance.",
+		}},
+	}
+
+	if len(columns) == 0 {
+		columns = []apiextensionsv1.CustomResourceColumnDefinition{{
+			Name:        "Age",
+			Type:        "date",
+			Description: metav1.ObjectMeta{}.SwaggerDoc()["creationTimestamp"],
+			JSONPath:    ".metadata.creationTimestamp",
+		}}
+	}
+
+	for _, col := range columns {
+		path := jsonpath.New(col.Name)
+		if err := path.Parse(fmt.Sprintf("{%s}", col.JSONPath)); err != nil {
+			return nil, fmt.Errorf("unrecognized column definition %q", col.JSONPath)
+		}
+		path.AllowMissingKeys(true)
+
+		c.columns = append(c.columns, path)
+		c.headers = append(c.headers, metav1.TableColumnDefinition{
+			Name:        col.Name,
+			Type:        col.Type,
+			Format:      col.Format,
+			Description: col.Description,
+			Priority:    col.Priority,
+		})
+	}
+	return c, nil
+}
+
+// Headers returns the column definitions of the table.
+func (c *Convertor) Headers() []metav1.TableColumnDefinition {
+	return c.headers
+}
+
+// Table renders the instances as a metav1.Table.
+func (c *Convertor) Table(instances []*unstructured.Unstructured) *metav1.Table {
+	table := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metav1.SchemeGroupVersion.String(),
+			Kind:       "Table",
+		},
+		ColumnDefinitions: c.headers,
+		Rows:              make([]metav1.TableRow, 0, len(instances)),
+	}
+	for _, u := range instances {
+		table.Rows = append(table.Rows, c.Row(u))
+	}
+	return table
+}
+
+// Row renders an instance as a metav1.TableRow. The row object holds the
+// metadata of the instance.
+func (c *Convertor) Row(u *unstructured.Unstructured) metav1.TableRow {
+	cells := make([]interface{}, 0, len(c.headers))
+	cells = append(cells, u.GetName(), u.GroupVersionKind().GroupKind().String())
+
+	buf := &bytes.Buffer{}
+	for i, column := range c.columns {
+		results, err := column.FindResults(u.UnstructuredContent())
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			cells = append(cells, nil)
+			continue
+		}
+
+		// Printer columns are simple JSON paths, only the first result is used.
+		value := results[0][0].Interface()
+		header := c.headers[i+2]
+		if header.Type == "string" {
+			if err := column.PrintResults(buf, []reflect.Value{reflect.ValueOf(value)}); err == nil {
+				cells = append(cells, buf.String())
+				buf.Reset()
+			} else {
+				cells = append(cells, nil)
+			}
+		} else {
+			cells = append(cells, cellForJSONValue(header.Type, value))
+		}
+	}
+
+	pom := &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: u.GetAPIVersion(),
+			Kind:       u.GetKind(),
+		},
+	}
+	if m, ok := u.Object["metadata"].(map[string]interface{}); ok {
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(m, &pom.ObjectMeta)
+	}
+
+	return metav1.TableRow{
+		Cells:  cells,
+		Object: runtime.RawExtension{Object: pom},
+	}
+}
+
+// cellForJSONValue converts a value found by a printer column to the type of
+// the column, or nil if the value does not have that type.
+func cellForJSONValue(headerType string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch headerType {
+	case "integer":
+		switch typed := value.(type) {
+		case int64:
+			return typed
+		case float64:
+			return int64(typed)
+		case json.Number:
+			if i64, err := typed.Int64(); err == nil {
+				return i64
+			}
+		}
+	case "number":
+		switch typed := value.(type) {
+		case int64:
+			return float64(typed)
+		case float64:
+			return typed
+		case json.Number:
+			if f, err := typed.Float64(); err == nil {
+				return f
+			}
+		}
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b
+		}
+	case "string":
+		if s, ok := value.(string); ok {
+			return s
+		}
+	case "date":
+		if typed, ok := value.(string); ok {
+			var timestamp metav1.Time
+			if err := timestamp.UnmarshalQueryParameter(typed); err != nil {
+				return "<invalid>"
+			}
+			if timestamp.IsZero() {
+				return "<unknown>"
+			}
+			return duration.HumanDuration(now().Sub(timestamp.Time))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func addressable(apiVersion, kind, namespace, name, url string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetCreationTimestamp(metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if url != "" {
+		_ = unstructured.SetNestedField(u.Object, url, "status", "address", "url")
+	}
+	_ = unstructured.SetNestedField(u.Object, int64(3), "status", "observedGeneration")
+	return u
+}
+
+func cells(table *metav1.Table) [][]interface{} {
+	got := make([][]interface{}, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		got = append(got, row.Cells)
+	}
+	return got
+}
+
+func TestConvertor(t *testing.T) {
+	now = func() time.Time { return time.Date(2022, 1, 1, 0, 10, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	instances := []*unstructured.Unstructured{
+		addressable("eventing.knative.dev/v1", "Broker", "default", "default", "http://broker.default"),
+		addressable("messaging.knative.dev/v1", "Channel", "demo", "orders", ""),
+	}
+
+	tests := map[string]struct {
+		columns     []apiextensionsv1.CustomResourceColumnDefinition
+		wantHeaders []string
+		wantCells   [][]interface{}
+	}{
+		"no printer columns": {
+			wantHeaders: []string{"Name", "Kind", "Age"},
+			wantCells: [][]interface{}{
+				{"default", "Broker.eventing.knative.dev", "10m"},
+				{"orders", "Channel.messaging.knative.dev", "10m"},
+			},
+		},
+		"printer columns": {
+			columns: []apiextensionsv1.CustomResourceColumnDefinition{{
+				Name:     "URL",
+				Type:     "string",
+				JSONPath: ".status.address.url",
+			}, {
+				Name:     "Generation",
+				Type:     "integer",
+				JSONPath: ".status.observedGeneration",
+				Priority: 1,
+			}, {
+				Name:     "Created",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			}},
+			wantHeaders: []string{"Name", "Kind", "URL", "Generation", "Created"},
+			wantCells: [][]interface{}{
+				{"default", "Broker.eventing.knative.dev", "http://broker.default", int64(3), "10m"},
+				{"orders", "Channel.messaging.knative.dev", nil, int64(3), "10m"},
+			},
+		},
+		"mistyped column": {
+			columns: []apiextensionsv1.CustomResourceColumnDefinition{{
+				Name:     "URL",
+				Type:     "boolean",
+				JSONPath: ".status.address.url",
+			}},
+			wantHeaders: []string{"Name", "Kind", "URL"},
+			wantCells: [][]interface{}{
+				{"default", "Broker.eventing.knative.dev", nil},
+				{"orders", "Channel.messaging.knative.dev", nil},
+			},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			c, err := NewConvertor(tc.columns)
+			if err != nil {
+				t.Fatal("NewConvertor() =", err)
+			}
+			table := c.Table(instances)
+
+			headers := make([]string, 0, len(table.ColumnDefinitions))
+			for _, h := range table.ColumnDefinitions {
+				headers = append(headers, h.Name)
+			}
+			if diff := cmp.Diff(tc.wantHeaders, headers); diff != "" {
+				t.Error("headers (-want, +got):", diff)
+			}
+			if diff := cmp.Diff(tc.wantCells, cells(table)); diff != "" {
+				t.Error("cells (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestConvertor_RowObject(t *testing.T) {
+	c, err := NewConvertor(nil)
+	if err != nil {
+		t.Fatal("NewConvertor() =", err)
+	}
+	row := c.Row(addressable("eventing.knative.dev/v1", "Broker", "default", "default", ""))
+
+	pom, ok := row.Object.Object.(*metav1.PartialObjectMetadata)
+	if !ok {
+		t.Fatalf("expected the row object to be PartialObjectMetadata, got %T", row.Object.Object)
+	}
+	if pom.Kind != "Broker" || pom.Namespace != "default" || pom.Name != "default" {
+		t.Errorf("unexpected row object %v", pom)
+	}
+}
+
+func TestNewConvertor_BadColumn(t *testing.T) {
+	if _, err := NewConvertor([]apiextensionsv1.CustomResourceColumnDefinition{{
+		Name:     "Bad",
+		Type:     "string",
+		JSONPath: ".status[",
+	}}); err == nil {
+		t.Error("expected an error for a bad JSONPath")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/dynamic"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+// Table lists the instances of every implementer of the given version of the
+// duck type and renders them with the printer columns of that version. If
+// namespace is empty, instances in all namespaces are listed. If some kinds
+// cannot be listed, the table of the others is returned along with the error.
+func Table(ctx context.Context, client dynamic.Interface, dt *v1alpha1.ClusterDuckType, version, namespace string) (*metav1.Table, error) {
+	columns, err := Columns(dt, version)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewConvertor(columns)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := List(ctx, client, dt.Status.Ducks[version], namespace)
+	return c.Table(instances), err
+}
+
+// Columns returns the printer columns of the given version of the duck type.
+func Columns(dt *v1alpha1.ClusterDuckType, version string) ([]apiextensionsv1.CustomResourceColumnDefinition, error) {
+	for _, dv := range dt.Spec.Versions {
+		if dv.Name == version {
+			return dv.AdditionalPrinterColumns, nil
+		}
+	}
+	return nil, fmt.Errorf("version %q not found in %s", version, dt.Name)
+}
+
+// List lists the instances of the ducks. A kind is listed once, using its
+// preferred version, and cluster scoped ducks are skipped if a namespace is
+// given. Instances are ordered by kind, namespace and name. A kind that
+// cannot be listed does not stop the others: the instances of those are
+// returned along with an error aggregating the failure of each kind.
+func List(ctx context.Context, client dynamic.Interface, ducks []v1alpha1.ResourceMeta, namespace string) ([]*unstructured.Unstructured, error) {
+	instances := make([]*unstructured.Unstructured, 0)
+	var errs []error
+	for _, meta := range v1alpha1.PreferredVersions(ducks) {
+		gv, err := schema.ParseGroupVersion(meta.APIVersion)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		gk := gv.WithKind(meta.Kind).GroupKind()
+
+		if meta.Resource == "" {
+			errs = append(errs, fmt.Errorf("resource of %s %s is not known", meta.Kind, meta.APIVersion))
+			continue
+		}
+		ri := client.Resource(gv.WithResource(meta.Resource))
+
+		var list *unstructured.UnstructuredList
+		switch {
+		case meta.Scope == v1alpha1.ClusterScoped && namespace != "":
+			continue
+		case meta.Scope == v1alpha1.ClusterScoped:
+			list, err = ri.List(ctx, metav1.ListOptions{})
+		default:
+			list, err = ri.Namespace(namespace).List(ctx, metav1.ListOptions{})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s: %w", strings.ToLower(gk.String()), err))
+			continue
+		}
+
+		items := make([]*unstructured.Unstructured, 0, len(list.Items))
+		for i := range list.Items {
+			items = append(items, &list.Items[i])
+		}
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].GetNamespace() != items[j].GetNamespace() {
+				return items[i].GetNamespace() < items[j].GetNamespace()
+			}
+			return items[i].GetName() < items[j].GetName()
+		})
+		instances = append(instances, items...)
+	}
+	return instances, utilerrors.NewAggregate(errs)
+}
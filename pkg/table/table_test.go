@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"knative.dev/discovery/pkg/apis/discovery/v1alpha1"
+)
+
+func fakeClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "eventing.knative.dev", Version: "v1", Resource: "brokers"}:       "BrokerList",
+			{Group: "messaging.knative.dev", Version: "v1", Resource: "channels"}:     "ChannelList",
+			{Group: "sources.knative.dev", Version: "v1", Resource: "clustersources"}: "ClusterSourceList",
+		},
+		addressable("eventing.knative.dev/v1", "Broker", "default", "default", "http://broker.default"),
+		addressable("eventing.knative.dev/v1", "Broker", "demo", "b", "http://b.demo"),
+		addressable("eventing.knative.dev/v1", "Broker", "demo", "a", "http://a.demo"),
+		addressable("messaging.knative.dev/v1", "Channel", "demo", "orders", "http://orders.demo"),
+		addressable("sources.knative.dev/v1", "ClusterSource", "", "global", "http://global"),
+	)
+}
+
+var addressables = &v1alpha1.ClusterDuckType{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "addressables.duck.knative.dev",
+	},
+	Spec: v1alpha1.ClusterDuckTypeSpec{
+		Versions: []v1alpha1.DuckVersion{{
+			Name: "v1",
+			AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{{
+				Name:     "URL",
+				Type:     "string",
+				JSONPath: ".status.address.url",
+			}},
+		}},
+	},
+	Status: v1alpha1.ClusterDuckTypeStatus{
+		Ducks: map[string][]v1alpha1.ResourceMeta{
+			"v1": {{
+				APIVersion: "eventing.knative.dev/v1beta1",
+				Kind:       "Broker",
+				Resource:   "brokers",
+				Scope:      v1alpha1.NamespaceScoped,
+			}, {
+				APIVersion: "eventing.knative.dev/v1",
+				Kind:       "Broker",
+				Resource:   "brokers",
+				Scope:      v1alpha1.NamespaceScoped,
+				Preferred:  true,
+			}, {
+				APIVersion: "messaging.knative.dev/v1",
+				Kind:       "Channel",
+				Resource:   "channels",
+				Scope:      v1alpha1.NamespaceScoped,
+			}, {
+				APIVersion: "sources.knative.dev/v1",
+				Kind:       "ClusterSource",
+				Resource:   "clustersources",
+				Scope:      v1alpha1.ClusterScoped,
+			}},
+		},
+	},
+}
+
+func TestTable(t *testing.T) {
+	tests := map[string]struct {
+		namespace string
+		want      [][]interface{}
+	}{
+		"all namespaces": {
+			want: [][]interface{}{
+				{"default", "Broker.eventing.knative.dev", "http://broker.default"},
+				{"a", "Broker.eventing.knative.dev", "http://a.demo"},
+				{"b", "Broker.eventing.knative.dev", "http://b.demo"},
+				{"orders", "Channel.messaging.knative.dev", "http://orders.demo"},
+				{"global", "ClusterSource.sources.knative.dev", "http://global"},
+			},
+		},
+		"one namespace": {
+			namespace: "demo",
+			want: [][]interface{}{
+				{"a", "Broker.eventing.knative.dev", "http://a.demo"},
+				{"b", "Broker.eventing.knative.dev", "http://b.demo"},
+				{"orders", "Channel.messaging.knative.dev", "http://orders.demo"},
+			},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			table, err := Table(context.Background(), fakeClient(), addressables, "v1", tc.namespace)
+			if err != nil {
+				t.Fatal("Table() =", err)
+			}
+			if diff := cmp.Diff(tc.want, cells(table)); diff != "" {
+				t.Error("cells (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestTable_UnknownVersion(t *testing.T) {
+	if _, err := Table(context.Background(), fakeClient(), addressables, "v2", ""); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+}
+
+func TestList_UnknownResource(t *testing.T) {
+	instances, err := List(context.Background(), fakeClient(), []v1alpha1.ResourceMeta{{
+		APIVersion: "eventing.knative.dev/v1",
+		Kind:       "Broker",
+	}, {
+		APIVersion: "messaging.knative.dev/v1",
+		Kind:       "Channel",
+		Resource:   "channels",
+		Scope:      v1alpha1.NamespaceScoped,
+	}}, "")
+	if err == nil {
+		t.Error("expected an error for a duck without a resource")
+	}
+	// The other kinds are still listed.
+	if len(instances) != 1 || instances[0].GetName() != "orders" {
+		t.Errorf("instances = %v, want the orders channel", instances)
+	}
+}